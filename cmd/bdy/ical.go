@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/poiley/beady/internal/bd"
+	"github.com/poiley/beady/internal/ical"
+)
+
+// runICal implements `bdy ical`: write the due-date calendar to stdout or a
+// file, or with --serve host it on localhost for calendar subscriptions.
+func runICal(args []string) error {
+	fs := flag.NewFlagSet("ical", flag.ContinueOnError)
+	output := fs.String("o", "", "write to a file instead of stdout")
+	serve := fs.Bool("serve", false, "serve the feed over HTTP on localhost")
+	port := fs.Int("port", 8765, "port for --serve")
+	db := fs.String("db", "", "use this beads database (passed to bd; BEADS_DB also works)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bdy ical [--db PATH] [-o file | --serve [--port N]] [directory]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		workDir = fs.Arg(0)
+	}
+	client := &bd.Client{WorkDir: workDir, DB: *db}
+	if err := client.CheckInit(); err != nil {
+		return err
+	}
+	name := "bdy: " + filepath.Base(absPath(workDir))
+
+	if *serve {
+		return serveICal(client, name, *port)
+	}
+
+	issues, err := client.ListAll()
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		return ical.Write(os.Stdout, name, issues, time.Now())
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := ical.Write(f, name, issues, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// serveICal hosts the feed on the loopback interface only, regenerating it
+// from bd on every request so subscribers always see current dates.
+func serveICal(client *bd.Client, name string, port int) error {
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/calendar.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		issues, err := client.ListAll()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", ical.ContentType)
+		if err := ical.Write(w, name, issues, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "ical: %s\n", err)
+		}
+	})
+
+	fmt.Printf("Serving calendar at http://%s/calendar.ics (Ctrl+C to stop)\n", addr)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return srv.ListenAndServe()
+}
+
+// absPath returns an absolute path, or p unchanged if it can't be resolved.
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return p
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/poiley/beady/internal/app"
 	"github.com/poiley/beady/internal/bd"
-	"github.com/poiley/beady/internal/selfupdate"
+	"github.com/poiley/beady/internal/diag"
 )
 
 // Set via -ldflags at build time.
@@ -28,23 +31,48 @@ func main() {
 		case "--help", "-h", "help":
 			fmt.Println("bdy - a k9s-style TUI for beads issue tracking")
 			fmt.Printf("Version: %s\n\n", Version)
-			fmt.Println("Usage: bdy [directory]")
+			fmt.Println("Usage: bdy [--db PATH] [--log-file PATH] [directory]")
 			fmt.Println()
 			fmt.Println("Run bdy in a directory with beads initialized (bd init).")
 			fmt.Println("If no directory is given, uses the current working directory.")
 			fmt.Println()
 			fmt.Println("Commands:")
-			fmt.Println("  update             Check for and install the latest version")
+			fmt.Println("  update             Install the latest version, checksum-verified (--to, --rollback; --help for flags)")
 			fmt.Println("  version            Show version info")
 			fmt.Println("  check              Verify bd CLI is available and beads is initialized")
+			fmt.Println("  stats [--report]   Issue counts, or lead-time breakdowns (--help for flags)")
+			fmt.Println("  ical [--serve]     iCalendar feed of due and defer dates (--help for flags)")
 			fmt.Println()
 			fmt.Println("Flags:")
 			fmt.Println("  --version, -v      Show version")
 			fmt.Println("  --help, -h         Show this help")
 			fmt.Println("  --check            Same as 'check' command")
+			fmt.Println("  --db PATH          Use this beads database (passed to bd; BEADS_DB also works)")
+			fmt.Println("  --log-file PATH    Write a JSON diagnostics log (or set BDY_LOG)")
 			os.Exit(0)
 		case "update":
-			if err := selfupdate.Update(Version); err != nil {
+			if err := runUpdate(os.Args[2:]); err != nil {
+				if errors.Is(err, flag.ErrHelp) {
+					os.Exit(0)
+				}
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		case "stats":
+			if err := runStats(os.Args[2:]); err != nil {
+				if errors.Is(err, flag.ErrHelp) {
+					os.Exit(0)
+				}
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		case "ical":
+			if err := runICal(os.Args[2:]); err != nil {
+				if errors.Is(err, flag.ErrHelp) {
+					os.Exit(0)
+				}
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				os.Exit(1)
 			}
@@ -55,7 +83,12 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				os.Exit(1)
 			}
-			client := bd.NewClient(workDir)
+			args, err := parseArgs(os.Args[2:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			client := &bd.Client{WorkDir: workDir, DB: args.dbPath}
 			if err := client.CheckInit(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				os.Exit(1)
@@ -71,22 +104,78 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		os.Exit(1)
 	}
-	if len(os.Args) > 1 && os.Args[1][0] != '-' {
-		workDir = os.Args[1]
+	args, err := parseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	if args.dir != "" {
+		workDir = args.dir
+	}
+
+	// Open the diagnostics log before the first bd call so it is recorded.
+	var rec *diag.Recorder
+	if args.logFile != "" {
+		rec, err = diag.Open(args.logFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: opening log file: %s\n", err)
+			os.Exit(1)
+		}
+		defer rec.Close()
 	}
 
 	// Check bd is available
-	client := bd.NewClient(workDir)
+	client := &bd.Client{WorkDir: workDir, DB: args.dbPath, Diag: rec}
 	if err := client.CheckInit(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		os.Exit(1)
 	}
 
 	// Start TUI
-	model := app.New(workDir)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	model := app.New(workDir, app.Options{DBPath: args.dbPath, Diag: rec, Version: Version})
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithReportFocus())
 	if _, err := p.Run(); err != nil {
+		rec.Close()
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		os.Exit(1)
 	}
 }
+
+// tuiArgs are the TUI's command-line arguments.
+type tuiArgs struct {
+	dir     string
+	dbPath  string
+	logFile string
+}
+
+// parseArgs picks the optional directory, --db PATH and --log-file PATH
+// (either also as --flag=PATH) out of the TUI's arguments. BDY_LOG is the
+// default log file.
+func parseArgs(args []string) (tuiArgs, error) {
+	out := tuiArgs{logFile: os.Getenv("BDY_LOG")}
+	flags := map[string]*string{"--db": &out.dbPath, "--log-file": &out.logFile}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		if dst, ok := flags[name]; ok {
+			if !hasValue {
+				if i+1 >= len(args) {
+					return out, fmt.Errorf("%s needs a path", name)
+				}
+				i++
+				value = args[i]
+			}
+			*dst = value
+			continue
+		}
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			return out, fmt.Errorf("unknown flag %s (see bdy --help)", arg)
+		case out.dir == "":
+			out.dir = arg
+		default:
+			return out, fmt.Errorf("unexpected argument %q", arg)
+		}
+	}
+	return out, nil
+}
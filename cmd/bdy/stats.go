@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/poiley/beady/internal/analytics"
+	"github.com/poiley/beady/internal/bd"
+	"github.com/poiley/beady/internal/models"
+)
+
+// runStats implements `bdy stats`: the bd stats summary, or with --report a
+// lead-time breakdown of closed issues.
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	report := fs.Bool("report", false, "lead-time breakdown of closed issues")
+	asJSON := fs.Bool("json", false, "output JSON instead of a table")
+	since := fs.String("since", "30d", "window start: a date (2006-01-02), a day count (90d), or \"all\"; the default counts back from --until")
+	until := fs.String("until", "", "window end date (2006-01-02), default now")
+	by := fs.String("by", "type,assignee,label,priority", "comma-separated groupings")
+	db := fs.String("db", "", "use this beads database (passed to bd; BEADS_DB also works)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bdy stats [--report] [flags] [directory]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		workDir = fs.Arg(0)
+	}
+	client := &bd.Client{WorkDir: workDir, DB: *db}
+	if err := client.CheckInit(); err != nil {
+		return err
+	}
+
+	if !*report {
+		stats, err := client.Stats()
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return writeJSON(os.Stdout, stats)
+		}
+		printSummary(os.Stdout, stats)
+		return nil
+	}
+
+	// The default 30 days count back from --until when it is given, so
+	// `--until` alone reports on the month before that date.
+	sinceSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "since" {
+			sinceSet = true
+		}
+	})
+	now := time.Now()
+	window, err := parseWindow(*since, *until, sinceSet, now)
+	if err != nil {
+		return err
+	}
+	dims, err := analytics.ParseDimensions(*by)
+	if err != nil {
+		return err
+	}
+	issues, err := client.ListAll()
+	if err != nil {
+		return err
+	}
+	r := analytics.BuildReport(issues, window, dims)
+	if *asJSON {
+		return writeJSON(os.Stdout, r)
+	}
+	printReport(os.Stdout, r)
+	return nil
+}
+
+// parseWindow turns the --since/--until flags into a window. --since takes a
+// date, a number of days such as "90d", or "all". A day count runs up to
+// now, or up to --until if --since wasn't given explicitly (sinceSet).
+func parseWindow(since, until string, sinceSet bool, now time.Time) (analytics.Window, error) {
+	var w analytics.Window
+	var untilT time.Time
+	if until != "" {
+		t, err := time.ParseInLocation("2006-01-02", until, time.Local)
+		if err != nil {
+			return w, fmt.Errorf("invalid --until %q: want 2006-01-02", until)
+		}
+		untilT = t.AddDate(0, 0, 1).Add(-time.Nanosecond) // inclusive
+	}
+
+	switch {
+	case since == "" || since == "all":
+	case strings.HasSuffix(since, "d"):
+		n, err := strconv.Atoi(strings.TrimSuffix(since, "d"))
+		if err != nil || n < 1 {
+			return w, fmt.Errorf("invalid --since %q", since)
+		}
+		end := now
+		if !sinceSet && !untilT.IsZero() {
+			end = untilT
+		}
+		w = analytics.LastDays(n, end)
+	default:
+		t, err := time.ParseInLocation("2006-01-02", since, time.Local)
+		if err != nil {
+			return w, fmt.Errorf("invalid --since %q: want 2006-01-02, 90d or all", since)
+		}
+		w.Since = t
+	}
+	if !untilT.IsZero() {
+		w.Until = untilT
+	}
+	if !w.Since.IsZero() && !w.Until.IsZero() && w.Since.After(w.Until) {
+		return w, fmt.Errorf("--since %s is after --until %s", w.Since.Format("2006-01-02"), w.Until.Format("2006-01-02"))
+	}
+	return w, nil
+}
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printSummary(out io.Writer, s *models.StatsSummary) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Total\t%d\n", s.TotalIssues)
+	fmt.Fprintf(tw, "Open\t%d\n", s.OpenIssues)
+	fmt.Fprintf(tw, "In progress\t%d\n", s.InProgressIssues)
+	fmt.Fprintf(tw, "Blocked\t%d\n", s.BlockedIssues)
+	fmt.Fprintf(tw, "Deferred\t%d\n", s.DeferredIssues)
+	fmt.Fprintf(tw, "Ready\t%d\n", s.ReadyIssues)
+	fmt.Fprintf(tw, "Closed\t%d\n", s.ClosedIssues)
+	fmt.Fprintf(tw, "Avg lead time\t%s\n", models.FormatDuration(time.Duration(s.AvgLeadTimeHours*float64(time.Hour))))
+	tw.Flush()
+}
+
+func printReport(out io.Writer, r *analytics.Report) {
+	fmt.Fprintf(out, "Lead time of issues closed %s\n\n", describeWindow(r.Window))
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	writeGroup := func(g analytics.GroupStats) {
+		est := "-"
+		if g.Estimated > 0 {
+			est = fmt.Sprintf("%.1fx (%d)", g.EstimateRatio, g.Estimated)
+		}
+		fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\t%s\t%s\n", g.Key, g.Count,
+			models.FormatDuration(g.Mean), models.FormatDuration(g.Median), models.FormatDuration(g.P90), est)
+	}
+	header := func(title string) {
+		fmt.Fprintf(tw, "%s\tCOUNT\tMEAN\tMEDIAN\tP90\tACTUAL/EST\n", strings.ToUpper(title))
+	}
+	header("overall")
+	writeGroup(r.Overall)
+	for _, b := range r.Breakdowns {
+		fmt.Fprintln(tw, "\t\t\t\t\t")
+		header("by " + string(b.Dimension))
+		for _, g := range b.Groups {
+			writeGroup(g)
+		}
+	}
+	tw.Flush()
+}
+
+func describeWindow(w analytics.Window) string {
+	switch {
+	case w.Since.IsZero() && w.Until.IsZero():
+		return "at any time"
+	case w.Until.IsZero():
+		return "since " + w.Since.Format("2006-01-02")
+	case w.Since.IsZero():
+		return "through " + w.Until.Format("2006-01-02")
+	}
+	return w.Since.Format("2006-01-02") + " through " + w.Until.Format("2006-01-02")
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/selfupdate"
+)
+
+// runUpdate implements `bdy update`: download the newest release on the
+// channel (or the one --to names), verify it against the published
+// checksums (and optionally a signature), and replace the running binary.
+// --rollback swaps back to the binary the last update replaced. Homebrew
+// and go installs are updated through those tools unless --force is set.
+func runUpdate(args []string) error {
+	// A broken config file just means the default channel.
+	cfg, _ := config.Load()
+
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	channel := fs.String("channel", cfg.Update.Channel, "release channel: stable or prerelease (default stable)")
+	to := fs.String("to", "", "install this release, e.g. v1.2.0, even if it is older")
+	rollback := fs.Bool("rollback", false, "restore the binary the last update replaced")
+	force := fs.Bool("force", false, "replace the binary even if Homebrew or go install manages it")
+	baseURL := fs.String("base-url", os.Getenv("BDY_UPDATE_URL"), "GitHub API base URL (default https://api.github.com)")
+	verify := fs.String("verify", "", "also verify the checksums signature: cosign or minisign")
+	key := fs.String("key", "", "public key for --verify (cosign: key file, or empty for keyless; minisign: key or key file)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bdy update [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if *rollback {
+		if *to != "" {
+			return fmt.Errorf("--rollback and --to can't be combined")
+		}
+		return selfupdate.Rollback(selfupdate.Options{Force: *force})
+	}
+	return selfupdate.Update(Version, selfupdate.Options{
+		BaseURL: *baseURL,
+		Verify:  *verify,
+		Key:     *key,
+		Channel: *channel,
+		To:      *to,
+		Force:   *force,
+	})
+}
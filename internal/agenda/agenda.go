@@ -0,0 +1,154 @@
+// Package agenda places issues on a calendar by due date, and deferred
+// issues on the date they wake up.
+package agenda
+
+import (
+	"sort"
+	"time"
+
+	"github.com/poiley/beady/internal/analytics"
+	"github.com/poiley/beady/internal/models"
+)
+
+// Kind says why an issue is on a date.
+type Kind int
+
+const (
+	KindDue   Kind = iota // the issue is due
+	KindWakes             // the issue's deferral ends
+)
+
+// String returns "due" or "wakes".
+func (k Kind) String() string {
+	if k == KindWakes {
+		return "wakes"
+	}
+	return "due"
+}
+
+// Entry is one issue on one date.
+type Entry struct {
+	Issue models.Issue
+	At    time.Time
+	Kind  Kind
+}
+
+// Overdue reports whether the entry is a missed due date.
+func (e Entry) Overdue(now time.Time) bool {
+	return e.Kind == KindDue && e.Issue.IsOverdue(now)
+}
+
+// Entries returns the dated entries for unfinished issues, sorted by time:
+// a due entry for every open issue with a due date, and a wake entry for
+// every issue deferred until a future date.
+func Entries(issues []models.Issue, now time.Time) []Entry {
+	var entries []Entry
+	for _, issue := range issues {
+		if issue.Status == "closed" {
+			continue
+		}
+		if issue.DueAt != nil {
+			entries = append(entries, Entry{Issue: issue, At: *issue.DueAt, Kind: KindDue})
+		}
+		if issue.DeferUntil != nil && issue.DeferUntil.After(now) {
+			entries = append(entries, Entry{Issue: issue, At: *issue.DeferUntil, Kind: KindWakes})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.Before(entries[j].At)
+		}
+		return entries[i].Issue.Priority < entries[j].Issue.Priority
+	})
+	return entries
+}
+
+// Bucket is an agenda section.
+type Bucket int
+
+const (
+	Overdue Bucket = iota
+	Today
+	ThisWeek
+	NextWeek
+	Later
+)
+
+// String returns the section title.
+func (b Bucket) String() string {
+	switch b {
+	case Overdue:
+		return "OVERDUE"
+	case Today:
+		return "TODAY"
+	case ThisWeek:
+		return "THIS WEEK"
+	case NextWeek:
+		return "NEXT WEEK"
+	default:
+		return "LATER"
+	}
+}
+
+// Section is a bucket and its entries, in time order.
+type Section struct {
+	Bucket  Bucket
+	Entries []Entry
+}
+
+// WeekStart returns the Monday on or before t, at local midnight.
+func WeekStart(t time.Time) time.Time {
+	day := analytics.Day(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
+
+// BucketOf places an entry relative to now by calendar day, so something
+// due earlier today is under Today (still styled overdue), not Overdue.
+// Weeks run Monday to Sunday; "this week" is the rest of the current week
+// after today.
+func BucketOf(e Entry, now time.Time) Bucket {
+	today := analytics.Day(now)
+	day := analytics.Day(e.At)
+	nextWeek := WeekStart(now).AddDate(0, 0, 7)
+	switch {
+	case day.Before(today):
+		return Overdue
+	case day.Equal(today):
+		return Today
+	case day.Before(nextWeek):
+		return ThisWeek
+	case day.Before(nextWeek.AddDate(0, 0, 7)):
+		return NextWeek
+	default:
+		return Later
+	}
+}
+
+// Group splits time-ordered entries into agenda sections, omitting empty
+// ones.
+func Group(entries []Entry, now time.Time) []Section {
+	var sections []Section
+	for b := Overdue; b <= Later; b++ {
+		s := Section{Bucket: b}
+		for _, e := range entries {
+			if BucketOf(e, now) == b {
+				s.Entries = append(s.Entries, e)
+			}
+		}
+		if len(s.Entries) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+// ByDay indexes entries by local day.
+func ByDay(entries []Entry) map[time.Time][]Entry {
+	days := make(map[time.Time][]Entry)
+	for _, e := range entries {
+		d := analytics.Day(e.At)
+		days[d] = append(days[d], e)
+	}
+	return days
+}
@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// Dimension is an issue attribute closed issues can be grouped by.
+type Dimension string
+
+const (
+	ByType     Dimension = "type"
+	ByAssignee Dimension = "assignee"
+	ByLabel    Dimension = "label"
+	ByPriority Dimension = "priority"
+)
+
+// Dimensions lists every dimension in report order.
+var Dimensions = []Dimension{ByType, ByAssignee, ByLabel, ByPriority}
+
+// ParseDimensions parses a comma-separated list such as "type,label".
+func ParseDimensions(s string) ([]Dimension, error) {
+	var dims []Dimension
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		found := false
+		for _, d := range Dimensions {
+			if string(d) == part {
+				dims = append(dims, d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown dimension %q (want type, assignee, label or priority)", part)
+		}
+	}
+	return dims, nil
+}
+
+// keys returns the groups an issue belongs to along a dimension. An issue
+// with several labels counts once in each label's group.
+func (d Dimension) keys(issue models.Issue) []string {
+	switch d {
+	case ByType:
+		if issue.IssueType == "" {
+			return []string{"(none)"}
+		}
+		return []string{issue.IssueType}
+	case ByAssignee:
+		if issue.Assignee == "" {
+			return []string{"(unassigned)"}
+		}
+		return []string{issue.Assignee}
+	case ByLabel:
+		if len(issue.Labels) == 0 {
+			return []string{"(none)"}
+		}
+		return issue.Labels
+	case ByPriority:
+		return []string{issue.PriorityString()}
+	}
+	return nil
+}
+
+// Window is a date range over close times. A zero Since or Until leaves
+// that end open.
+type Window struct {
+	Since time.Time `json:"since,omitzero"`
+	Until time.Time `json:"until,omitzero"`
+}
+
+// Contains reports whether t falls inside the window.
+func (w Window) Contains(t time.Time) bool {
+	if !w.Since.IsZero() && t.Before(w.Since) {
+		return false
+	}
+	if !w.Until.IsZero() && t.After(w.Until) {
+		return false
+	}
+	return true
+}
+
+// LastDays returns the window covering the last n days up to now.
+func LastDays(n int, now time.Time) Window {
+	return Window{Since: Day(now).AddDate(0, 0, -(n - 1)), Until: now}
+}
+
+// GroupStats summarizes the lead times of one group of closed issues.
+type GroupStats struct {
+	Key    string        `json:"key"`
+	Count  int           `json:"count"`
+	Mean   time.Duration `json:"-"`
+	Median time.Duration `json:"-"`
+	P90    time.Duration `json:"-"`
+
+	// Estimated is how many issues in the group had an estimate.
+	// EstimateRatio is the median of lead time over estimated work time
+	// (8h workdays) for those issues: above 1 means work took longer than
+	// estimated. Zero when no issue had an estimate.
+	Estimated     int     `json:"estimated"`
+	EstimateRatio float64 `json:"estimate_ratio,omitempty"`
+}
+
+// MarshalJSON reports durations in hours, matching bd's
+// average_lead_time_hours.
+func (g GroupStats) MarshalJSON() ([]byte, error) {
+	type plain GroupStats
+	return json.Marshal(struct {
+		plain
+		MeanHours   float64 `json:"mean_hours"`
+		MedianHours float64 `json:"median_hours"`
+		P90Hours    float64 `json:"p90_hours"`
+	}{plain(g), g.Mean.Hours(), g.Median.Hours(), g.P90.Hours()})
+}
+
+// Breakdown is the grouped stats along one dimension, largest group first.
+type Breakdown struct {
+	Dimension Dimension    `json:"dimension"`
+	Groups    []GroupStats `json:"groups"`
+}
+
+// Report is a lead-time report over the issues closed in a window.
+type Report struct {
+	Window     Window      `json:"window"`
+	Overall    GroupStats  `json:"overall"`
+	Breakdowns []Breakdown `json:"breakdowns"`
+}
+
+// BuildReport groups the issues closed inside the window along each
+// dimension. bd does not record when work started, so every figure is lead
+// time (created to closed).
+func BuildReport(issues []models.Issue, window Window, dims []Dimension) *Report {
+	var closed []models.Issue
+	for _, issue := range issues {
+		if issue.ClosedAt != nil && issue.LeadTime() > 0 && window.Contains(*issue.ClosedAt) {
+			closed = append(closed, issue)
+		}
+	}
+
+	r := &Report{Window: window, Overall: groupStats("all", closed)}
+	for _, d := range dims {
+		groups := make(map[string][]models.Issue)
+		for _, issue := range closed {
+			for _, k := range d.keys(issue) {
+				groups[k] = append(groups[k], issue)
+			}
+		}
+		b := Breakdown{Dimension: d}
+		for k, members := range groups {
+			b.Groups = append(b.Groups, groupStats(k, members))
+		}
+		sort.Slice(b.Groups, func(i, j int) bool {
+			if b.Groups[i].Count != b.Groups[j].Count {
+				return b.Groups[i].Count > b.Groups[j].Count
+			}
+			return b.Groups[i].Key < b.Groups[j].Key
+		})
+		r.Breakdowns = append(r.Breakdowns, b)
+	}
+	return r
+}
+
+func groupStats(key string, issues []models.Issue) GroupStats {
+	g := GroupStats{Key: key, Count: len(issues)}
+	leads := LeadTimes(issues)
+	g.Mean = Mean(leads)
+	g.Median = Percentile(leads, 50)
+	g.P90 = Percentile(leads, 90)
+
+	var ratios []float64
+	for _, issue := range issues {
+		if issue.EstimatedMinutes <= 0 {
+			continue
+		}
+		ratios = append(ratios, workHours(issue.LeadTime())/(float64(issue.EstimatedMinutes)/60))
+	}
+	g.Estimated = len(ratios)
+	if len(ratios) > 0 {
+		sort.Float64s(ratios)
+		g.EstimateRatio = ratios[(len(ratios)-1)/2]
+	}
+	return g
+}
+
+// workHours converts elapsed time to working hours at 8h per day, the same
+// convention Issue.EstimateString uses for estimates.
+func workHours(d time.Duration) float64 {
+	return d.Hours() / 24 * 8
+}
@@ -0,0 +1,174 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// Day truncates t to local midnight.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// Daily holds per-day counts over a window of days ending today.
+type Daily struct {
+	Start   time.Time // first day (local midnight)
+	Created []int     // issues created per day
+	Closed  []int     // issues closed per day
+	Open    []int     // issues open at the end of each day (burndown)
+}
+
+// Days returns the number of days in the series.
+func (d *Daily) Days() int {
+	return len(d.Created)
+}
+
+// DailySeries buckets created and closed events into days for the window
+// [now-days+1, now] and computes how many issues were open at the end of
+// each day. Issues closed without a ClosedAt are treated as still open.
+func DailySeries(issues []models.Issue, days int, now time.Time) *Daily {
+	if days < 1 {
+		days = 1
+	}
+	start := Day(now).AddDate(0, 0, -(days - 1))
+	d := &Daily{
+		Start:   start,
+		Created: make([]int, days),
+		Closed:  make([]int, days),
+		Open:    make([]int, days),
+	}
+	index := func(t time.Time) int {
+		return int(math.Floor(Day(t).Sub(start).Hours()/24 + 0.5))
+	}
+	for _, issue := range issues {
+		if i := index(issue.CreatedAt); i >= 0 && i < days {
+			d.Created[i]++
+		}
+		if issue.ClosedAt != nil {
+			if i := index(*issue.ClosedAt); i >= 0 && i < days {
+				d.Closed[i]++
+			}
+		}
+		// Open at end of day i if created on or before i and not closed by then.
+		first := max(index(issue.CreatedAt), 0)
+		last := days - 1
+		if issue.ClosedAt != nil {
+			last = min(last, index(*issue.ClosedAt)-1)
+		}
+		for i := first; i <= last; i++ {
+			d.Open[i]++
+		}
+	}
+	return d
+}
+
+// Cumulative returns the running total of counts.
+func Cumulative(counts []int) []int {
+	out := make([]int, len(counts))
+	sum := 0
+	for i, c := range counts {
+		sum += c
+		out[i] = sum
+	}
+	return out
+}
+
+// Weekly sums a daily series into weeks, aligned so the last bucket ends on
+// the last day. A partial first week is dropped.
+func Weekly(daily []int) []int {
+	var weeks []int
+	for end := len(daily); end-7 >= 0; end -= 7 {
+		sum := 0
+		for _, c := range daily[end-7 : end] {
+			sum += c
+		}
+		weeks = append([]int{sum}, weeks...)
+	}
+	return weeks
+}
+
+// LeadTimes returns the lead times of closed issues.
+func LeadTimes(issues []models.Issue) []time.Duration {
+	var out []time.Duration
+	for i := range issues {
+		if lt := issues[i].LeadTime(); lt > 0 {
+			out = append(out, lt)
+		}
+	}
+	return out
+}
+
+// Percentile returns the p-th percentile (0-100) of durations using
+// nearest-rank on a sorted copy. Returns 0 for an empty slice.
+func Percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// Mean returns the arithmetic mean of durations, or 0.
+func Mean(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum / time.Duration(len(ds))
+}
+
+// Bucket is one histogram bin.
+type Bucket struct {
+	Label string
+	Count int
+}
+
+// leadTimeBins are the histogram bin upper bounds.
+var leadTimeBins = []struct {
+	label string
+	upTo  time.Duration
+}{
+	{"<1h", time.Hour},
+	{"<4h", 4 * time.Hour},
+	{"<1d", 24 * time.Hour},
+	{"<2d", 48 * time.Hour},
+	{"<1w", 7 * 24 * time.Hour},
+	{"<2w", 14 * 24 * time.Hour},
+	{"<1mo", 30 * 24 * time.Hour},
+	{"1mo+", math.MaxInt64},
+}
+
+// LeadTimeHistogram bins lead times on a roughly logarithmic scale.
+func LeadTimeHistogram(ds []time.Duration) []Bucket {
+	buckets := make([]Bucket, len(leadTimeBins))
+	for i, b := range leadTimeBins {
+		buckets[i].Label = b.label
+	}
+	for _, d := range ds {
+		for i, b := range leadTimeBins {
+			if d < b.upTo {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	return buckets
+}
+
+// StatusCounts tallies issues by status.
+func StatusCounts(issues []models.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Status]++
+	}
+	return counts
+}
@@ -11,7 +11,14 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/poiley/beady/internal/bd"
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/diag"
+	"github.com/poiley/beady/internal/graph"
 	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/notify"
+	"github.com/poiley/beady/internal/search"
+	"github.com/poiley/beady/internal/selfupdate"
+	"github.com/poiley/beady/internal/triage"
 	"github.com/poiley/beady/internal/ui"
 	"github.com/poiley/beady/internal/views"
 )
@@ -22,6 +29,23 @@ type ViewMode int
 const (
 	ViewList ViewMode = iota
 	ViewDetail
+	ViewActivity
+	ViewNotifications
+	ViewNext
+	ViewCritical
+	ViewMetrics
+	ViewAgenda
+	ViewSearch
+	ViewHistory
+)
+
+// metricsScope selects which issues the metrics view charts.
+type metricsScope int
+
+const (
+	metricsScopeFilter metricsScope = iota // issues matching the list filter
+	metricsScopeAll                        // every issue
+	metricsScopeEpic                       // descendants of an epic
 )
 
 // dataLoadedMsg is sent when data is loaded from bd.
@@ -30,7 +54,8 @@ type dataLoadedMsg struct {
 	readyIssues []models.Issue
 	stats       *models.StatsSummary
 	err         error
-	quiet       bool // true for auto-refresh (don't flash loading screen)
+	quiet       bool          // true for auto-refresh (don't flash loading screen)
+	took        time.Duration // how long the bd calls took
 }
 
 // detailLoadedMsg is sent when a detail view loads.
@@ -40,100 +65,464 @@ type detailLoadedMsg struct {
 	quiet bool // true for auto-refresh
 }
 
+// notifySentMsg reports the result of delivering notifications.
+type notifySentMsg struct {
+	err error
+}
+
+// claimDoneMsg reports the result of claiming an issue.
+type claimDoneMsg struct {
+	id  string
+	err error
+}
+
+// indexBatchMsg delivers full issue bodies fetched for the search index.
+type indexBatchMsg struct {
+	ids    []string // the issues asked for
+	issues []models.Issue
+	err    error
+}
+
+// indexBatchSize is how many issues one bd show call fetches for indexing.
+const indexBatchSize = 50
+
 // statusClearMsg signals that the status message should be cleared.
 type statusClearMsg struct{}
 
 // App is the root Bubble Tea model.
 type App struct {
 	client   *bd.Client
+	diag     *diag.Recorder
 	workDir  string
 	watcher  *dbWatcher
 	list     *views.ListView
 	detail   *views.DetailView
+	activity *views.ActivityView
+	notifs   *views.NotificationsView
+	next     *views.NextView
+	critical *views.CriticalPathView // nil until opened
+	metrics  *views.MetricsView
+	agenda   *views.AgendaView
+	search   *views.SearchView
 	help     *views.HelpView
-	viewMode ViewMode
-	showHelp bool
-	width    int
-	height   int
-	err      error
-	loading  bool
+
+	historyView *views.HistoryView
+	debugView   *views.DebugView
+	errorView   *views.DebugView
+	viewMode    ViewMode
+	showHelp    bool
+	showDebug   bool
+	showErrors  bool
+	width       int
+	height      int
+	err         error
+	loading     bool
 
 	// Temporary status bar message (e.g., "copied kubrick-drj").
 	statusMsg string
 
 	// Navigation stack for detail -> dependency drill-down
 	detailStack []*views.DetailView
+
+	// View to return to when the detail stack is exhausted.
+	detailReturn ViewMode
+
+	// Back/forward history across list states and detail pages (see
+	// history.go), the list state it last recorded, and the persisted
+	// recently viewed issues shown by the history picker, which are
+	// written out when the terminal loses focus and on quit.
+	history       navHistory
+	listMark      views.ListState
+	recent        *config.Recent
+	recentDirty   bool
+	historyReturn ViewMode
+
+	// The user's personal stars (see stars.go), kept locally rather than
+	// in the shared database.
+	stars    *config.Stars
+	starsErr error // why stars.json failed to load, if it did
+
+	// Detail views show long-text fields as raw source (toggled with M).
+	rawMarkdown bool
+
+	// Checklist toggles waiting for an earlier save of the same issue
+	// (see checklist.go). An issue has an entry while a save is running.
+	checklistQueue map[string][]views.ToggleChecklistMsg
+
+	// Split-pane layout (see split.go): the list shares the screen with a
+	// preview of the issue under the cursor.
+	split      bool
+	splitRatio float64 // list pane's share of the screen
+	preview    *views.DetailView
+	previewID  string
+	previewSeq int // bumped on every cursor move to debounce bd show
+
+	// Full issues from bd show, reused while still current (see cache.go),
+	// and the background fetches that keep it warm.
+	cache       *issueCache
+	prefetching map[string]bool // issue IDs being prefetched
+	prefetchSeq int             // bumped on every cursor move to debounce prefetch
+
+	// Where the critical path view returns to, including the detail chain
+	// it was opened from (nil when opened from the list).
+	criticalReturn ViewMode
+	criticalSaved  *detailState
+
+	// Metrics view scope and the view it returns to.
+	metricsScope  metricsScope
+	metricsEpic   string // epic for metricsScopeEpic, "" if none was selected
+	metricsReturn ViewMode
+
+	// Full-text search index. It is built on first use: issue bodies are
+	// fetched in batches, and issues whose UpdatedAt moves are re-fetched
+	// after each data load. Issues bd show fails on aren't retried until
+	// they change.
+	index         *search.Index
+	indexEnabled  bool
+	indexQueue    []string             // issue IDs waiting to be fetched
+	indexInFlight map[string]bool      // issues in the batch being fetched
+	indexFailed   map[string]time.Time // issue ID → UpdatedAt when fetching it failed
+
+	// Whether the terminal has focus, as reported by focus events. Polling
+	// pauses while it doesn't (see watcher.go).
+	focused bool
+
+	// Data load counts and latency, for the debug overlay (see debug.go),
+	// and background refreshes failing in a row (see failures.go).
+	refreshStats refreshStats
+	failures     refreshFailures
+
+	// Latest successful data load, shared by views that derive from it,
+	// and what incremental refresh needs to update it (see delta.go).
+	issues      []models.Issue
+	readyIssues []models.Issue
+	stats       *models.StatsSummary
+	delta       deltaState
+
+	// Running bdy version, for the update check (see updatecheck.go).
+	version string
+
+	// User configuration and watch notifications.
+	cfg       *config.Config
+	cfgErr    error  // why the config file failed to load, if it did
+	me        string // current user, for claiming and assignee matching
+	notifier  *notify.Notifier
+	watchSnap notify.Snapshot // previous data load, for watch evaluation
+}
+
+// detailState saves the detail navigation chain while another view is on top.
+type detailState struct {
+	detail *views.DetailView
+	stack  []*views.DetailView
+	ret    ViewMode
+}
+
+// Options are startup settings from the command line.
+type Options struct {
+	// DBPath selects the beads database, passed to bd as --db. Empty
+	// means let bd find it.
+	DBPath string
+
+	// Diag records diagnostics, to a log file if the user asked for one.
+	// Nil keeps them in memory only, for the diagnostics overlay.
+	Diag *diag.Recorder
+
+	// Version is the running bdy version, compared against the newest
+	// release when the update check is enabled.
+	Version string
 }
 
 // New creates a new App model.
-func New(workDir string) *App {
+func New(workDir string, opts Options) *App {
+	// A broken config file falls back to defaults rather than blocking
+	// startup; Init reports it, and cfg refuses to save over it.
+	cfg, cfgErr := config.Load()
+	notifs := views.NewNotificationsView()
+	notifs.SetWatches(cfg.Watches)
+	index := search.New()
+	stars, starsErr := config.LoadStars(workDir)
+	rec := opts.Diag
+	if rec == nil {
+		rec = diag.New()
+	}
+	client := &bd.Client{WorkDir: workDir, DB: opts.DBPath, Diag: rec}
+	watcher := newDBWatcher(workDir, opts.DBPath, client)
+	rec.Event("watcher started", "health", watcher.health.String(), "reason", watcher.reason)
+	list := views.NewListView()
+	list.SetStars(stars.IDs())
+	list.SetWatchHealth(watcher.health)
 	return &App{
-		client:   bd.NewClient(workDir),
-		workDir:  workDir,
-		watcher:  newDBWatcher(workDir),
-		list:     views.NewListView(),
-		help:     views.NewHelpView(),
-		viewMode: ViewList,
-		loading:  true,
+		client:         client,
+		diag:           rec,
+		workDir:        workDir,
+		watcher:        watcher,
+		list:           list,
+		activity:       views.NewActivityView(),
+		notifs:         notifs,
+		next:           views.NewNextView(),
+		metrics:        views.NewMetricsView(),
+		agenda:         views.NewAgendaView(),
+		search:         views.NewSearchView(index),
+		index:          index,
+		help:           views.NewHelpView(),
+		historyView:    views.NewHistoryView(),
+		debugView:      views.NewDebugView("Diagnostics"),
+		errorView:      views.NewDebugView("Refresh errors"),
+		recent:         config.LoadRecent(workDir),
+		stars:          stars,
+		cache:          newIssueCache(issueCacheSize),
+		prefetching:    make(map[string]bool),
+		indexInFlight:  make(map[string]bool),
+		indexFailed:    make(map[string]time.Time),
+		checklistQueue: make(map[string][]views.ToggleChecklistMsg),
+		splitRatio:     defaultSplitRatio,
+		viewMode:       ViewList,
+		focused:        true,
+		delta:          deltaState{supported: true},
+		loading:        true,
+		version:        selfupdate.BuildVersion(opts.Version),
+		cfg:            cfg,
+		cfgErr:         cfgErr,
+		starsErr:       starsErr,
+		me:             cfg.Me(),
+		notifier:       notify.NewNotifier(cfg.Notify),
 	}
 }
 
 // Init runs the initial command.
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(
-		a.loadData(),
-		a.watcher.waitForChange(),
-	)
+	cmds := []tea.Cmd{a.loadData(), a.watcher.waitForChange(), a.resyncTick(), a.checkForUpdate()}
+	// Say why auto-refresh is degraded, or local files didn't load, rather
+	// than failing silently.
+	var problems []string
+	if a.watcher.health != views.WatchLive {
+		problems = append(problems, a.watcher.health.String()+": "+a.watcher.reason)
+	}
+	if a.cfgErr != nil {
+		a.diag.Error("loading config", a.cfgErr)
+		problems = append(problems, "config not loaded, using defaults: "+firstLine(a.cfgErr.Error()))
+	}
+	if a.starsErr != nil {
+		a.diag.Error("loading stars", a.starsErr)
+		problems = append(problems, "stars not loaded: "+firstLine(a.starsErr.Error()))
+	}
+	if len(problems) > 0 {
+		cmds = append(cmds, a.setStatus(strings.Join(problems, "; ")))
+	}
+	return tea.Batch(cmds...)
 }
 
-// Update is the Bubble Tea update function.
+// Update is the Bubble Tea update function. It times each message for
+// the diagnostics log; see update for the handling itself.
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	start := time.Now()
+	m, cmd := a.update(msg)
+	if took := time.Since(start); took >= slowUpdate || isLoadMsg(msg) {
+		a.diag.Event("update", "type", fmt.Sprintf("%T", msg), "duration_us", took.Microseconds())
+	}
+	return m, cmd
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
-		a.list.SetSize(msg.Width, msg.Height)
+		a.layout()
 		if a.detail != nil {
 			a.detail.SetSize(msg.Width, msg.Height)
 		}
+		a.activity.SetSize(msg.Width, msg.Height)
+		a.notifs.SetSize(msg.Width, msg.Height)
+		a.next.SetSize(msg.Width, msg.Height)
+		if a.critical != nil {
+			a.critical.SetSize(msg.Width, msg.Height)
+		}
+		a.metrics.SetSize(msg.Width, msg.Height)
+		a.agenda.SetSize(msg.Width, msg.Height)
+		a.search.SetSize(msg.Width, msg.Height)
+		a.historyView.SetSize(msg.Width, msg.Height)
 		a.help.SetSize(msg.Width, msg.Height)
+		a.debugView.SetSize(msg.Width, msg.Height)
+		a.errorView.SetSize(msg.Width, msg.Height)
 		return a, nil
 
 	case fileChangedMsg:
 		// Database changed on disk — silently reload data in the background.
-		// Don't set a.loading (no loading screen flash).
-		var reloadCmd tea.Cmd
-		switch a.viewMode {
-		case ViewList:
-			reloadCmd = a.loadDataQuiet()
-		case ViewDetail:
-			if a.detail != nil {
-				reloadCmd = a.loadDetailQuiet(a.detail.IssueID())
-			}
+		// Re-arm the watcher for the next change.
+		return a, tea.Batch(a.autoRefresh(), a.watcher.waitForChange())
+
+	case tea.BlurMsg:
+		a.focused = false
+		a.watcher.setPaused(true)
+		a.flushRecent()
+		return a, nil
+
+	case tea.FocusMsg:
+		if a.focused {
+			return a, nil
 		}
-		// Re-arm the watcher for the next change
-		return a, tea.Batch(reloadCmd, a.watcher.waitForChange())
+		// Catch up on anything polling would have seen while paused.
+		a.focused = true
+		a.watcher.setPaused(false)
+		return a, a.autoRefresh()
+
+	case deltaLoadedMsg:
+		return a, a.handleDelta(msg)
+
+	case refreshRetryMsg:
+		return a, a.retryRefresh(msg)
+
+	case updateCheckedMsg:
+		a.handleUpdateCheck(msg)
+		return a, nil
+
+	case resyncTickMsg:
+		// Reconcile with a full load now and then, even if nothing has
+		// triggered a refresh; skipped while the terminal is unfocused.
+		cmds := []tea.Cmd{a.resyncTick()}
+		if a.focused && !a.delta.lastFull.IsZero() && time.Since(a.delta.lastFull) >= fullResyncInterval {
+			cmds = append(cmds, a.loadDataQuiet())
+		}
+		return a, tea.Batch(cmds...)
+
+	case debugTickMsg:
+		if !a.showDebug {
+			return a, nil
+		}
+		a.debugView.SetSections(a.debugSections())
+		return a, a.debugTick()
 
 	case dataLoadedMsg:
+		a.refreshStats.record(msg.took, msg.quiet, msg.err)
+		a.diag.Error("refresh", msg.err)
 		if !msg.quiet {
 			a.loading = false
 		}
 		if msg.err != nil {
-			// Quiet refreshes silently ignore errors — stale data is better
-			// than flashing an error the user didn't ask for.
+			// Quiet refreshes keep the stale data on screen rather than
+			// flashing an error the user didn't ask for, but the header
+			// says so and a retry is scheduled.
 			if msg.quiet {
-				return a, nil
+				return a, a.refreshFailed(msg.err)
 			}
 			a.err = msg.err
 			return a, nil
 		}
 		a.err = nil
-		hasFlashes := a.list.SetData(msg.issues, msg.readyIssues, msg.stats)
-		if hasFlashes {
-			return a, tea.Tick(views.FlashDuration(), func(t time.Time) tea.Msg {
-				return views.FlashExpiredMsg{}
-			})
+		a.refreshSucceeded()
+		a.issues = msg.issues
+		a.readyIssues = msg.readyIssues
+		a.stats = msg.stats
+		a.delta.reset(msg.issues, msg.readyIssues)
+		a.cache.invalidate(updatedTimes(msg.issues))
+		changes := a.list.SetData(msg.issues, msg.readyIssues, msg.stats)
+		return a, a.dataChanged(changes)
+
+	case notifySentMsg:
+		if msg.err != nil {
+			return a, a.setStatus(msg.err.Error())
+		}
+		return a, nil
+
+	case views.ClaimIssueMsg:
+		if a.me == "" {
+			return a, a.setStatus("can't claim: set \"user\" in the config file")
+		}
+		return a, a.claim(msg.ID)
+
+	case claimDoneMsg:
+		if msg.err != nil {
+			return a, a.setStatus(fmt.Sprintf("claim %s failed: %s", msg.id, firstLine(msg.err.Error())))
+		}
+		return a, tea.Batch(
+			a.setStatus(fmt.Sprintf("claimed %s", msg.id)),
+			a.loadDataQuiet(),
+		)
+
+	case previewTickMsg:
+		if a.split && msg.seq == a.previewSeq && a.previewID != "" {
+			return a, a.loadPreview(a.previewID)
+		}
+		return a, nil
+
+	case previewLoadedMsg:
+		// Failures keep showing the list's copy of the issue.
+		if msg.err != nil {
+			return a, nil
+		}
+		a.cache.put(msg.issue)
+		a.list.UpdateChecklists([]models.Issue{*msg.issue})
+		if a.split && msg.issue.ID == a.previewID {
+			a.setPreview(msg.issue, true)
+		}
+		return a, nil
+
+	case prefetchTickMsg:
+		if msg.seq == a.prefetchSeq && a.viewMode == ViewList {
+			ids := a.list.IDsAround(prefetchRadius)
+			if a.split && len(ids) > 0 && ids[0] == a.previewID {
+				ids = ids[1:] // the preview fetches it
+			}
+			return a, a.prefetch(ids)
+		}
+		return a, nil
+
+	case prefetchedMsg:
+		for _, id := range msg.ids {
+			delete(a.prefetching, id)
+		}
+		for i := range msg.issues {
+			issue := &msg.issues[i]
+			a.cache.put(issue)
+			if a.indexEnabled {
+				a.index.Add(issue)
+			}
+			if a.split && issue.ID == a.previewID {
+				a.setPreview(issue, true)
+			}
 		}
+		a.list.UpdateChecklists(msg.issues)
+		return a, nil
+
+	case indexBatchMsg:
+		clear(a.indexInFlight)
+		a.noteIndexFailures(msg)
+		if msg.err != nil {
+			a.indexQueue = nil
+			a.updateIndexProgress()
+			return a, a.setStatus("indexing failed: " + firstLine(msg.err.Error()))
+		}
+		for i := range msg.issues {
+			a.index.Add(&msg.issues[i])
+		}
+		a.list.UpdateChecklists(msg.issues)
+		a.search.Refresh()
+		return a, a.fetchIndexBatch()
+
+	case views.ToggleChecklistMsg:
+		return a, a.queueChecklist(msg)
+
+	case checklistSavedMsg:
+		return a, a.checklistSaved(msg)
+
+	case views.AddWatchMsg:
+		if _, err := notify.ParseQuery(msg.Query); err != nil {
+			return a, a.setStatus(fmt.Sprintf("invalid query: %s", err))
+		}
+		a.cfg.AddQueryWatch(msg.Query)
+		return a, a.saveWatches(fmt.Sprintf("watching %q", msg.Query))
+
+	case views.RemoveWatchMsg:
+		a.cfg.RemoveWatch(msg.Index)
+		return a, a.saveWatches("watch removed")
+
+	case views.CycleMetricsScopeMsg:
+		a.metricsScope = (a.metricsScope + 1) % 3
+		if a.metricsScope == metricsScopeEpic && a.metricsEpic == "" {
+			a.metricsScope = metricsScopeFilter
+		}
+		a.refreshMetrics()
 		return a, nil
 
 	case views.FlashExpiredMsg:
@@ -143,62 +532,110 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case statusClearMsg:
 		a.statusMsg = ""
 		a.list.SetStatusMsg("")
+		a.activity.SetStatusMsg("")
+		a.notifs.SetStatusMsg("")
+		a.next.SetStatusMsg("")
+		a.metrics.SetStatusMsg("")
+		a.agenda.SetStatusMsg("")
+		a.search.SetStatusMsg("")
+		a.historyView.SetStatusMsg("")
+		if a.critical != nil {
+			a.critical.SetStatusMsg("")
+		}
 		if a.detail != nil {
 			a.detail.SetStatusMsg("")
 		}
 		return a, nil
 
 	case detailLoadedMsg:
+		a.diag.Error("detail", msg.err)
 		if !msg.quiet {
 			a.loading = false
 		}
 		if msg.err != nil {
 			if msg.quiet {
-				return a, nil
+				return a, a.refreshFailed(msg.err)
 			}
 			a.err = msg.err
 			return a, nil
 		}
 		a.err = nil
-		if msg.quiet && a.detail != nil {
-			// Quiet refresh: update the existing detail in-place
-			a.detail.UpdateIssue(msg.issue)
+		a.cache.put(msg.issue)
+		a.list.UpdateChecklists([]models.Issue{*msg.issue})
+		if a.indexEnabled {
+			a.index.Add(msg.issue)
+		}
+		if msg.quiet {
+			// Quiet refresh: update the existing detail in-place, unless
+			// the user has since moved on to another issue, or checklist
+			// toggles are still being saved and would be reverted (the
+			// detail is reloaded once they are; see checklist.go).
+			_, saving := a.checklistQueue[msg.issue.ID]
+			if a.detail != nil && a.detail.IssueID() == msg.issue.ID && !saving {
+				a.detail.UpdateIssue(msg.issue)
+			}
 		} else {
-			a.detail = views.NewDetailView(msg.issue)
-			a.detail.SetSize(a.width, a.height)
-			a.detail.SetBreadcrumbs(a.breadcrumbTrail())
-			a.viewMode = ViewDetail
+			a.showDetail(msg.issue)
 		}
-		return a, nil
+		return a, a.prefetchLinks(msg.issue)
+
+	case views.StarNoteMsg:
+		a.stars.SetNote(msg.ID, msg.Note, time.Now())
+		status := fmt.Sprintf("note saved on %s", msg.ID)
+		if msg.Note == "" {
+			status = fmt.Sprintf("note cleared on %s", msg.ID)
+		}
+		return a, a.saveStars(status)
 
 	case views.NavigateToIssueMsg:
 		// Drill-down: push current detail onto stack and load the new one.
-		if a.detail != nil {
+		// Coming from any other view starts a fresh detail chain that
+		// returns to that view. The history picker acts as if the issue
+		// had been opened from wherever the picker was.
+		if a.viewMode == ViewHistory {
+			a.closeHistory()
+		}
+		a.recordVisit()
+		if a.viewMode == ViewDetail && a.detail != nil {
 			a.detailStack = append(a.detailStack, a.detail)
+		} else {
+			a.detail = nil
+			a.detailStack = nil
+			a.detailReturn = a.viewMode
 		}
-		a.loading = true
-		return a, a.loadDetail(msg.ID)
+		return a, a.openDetail(msg.ID)
 
 	case tea.KeyMsg:
 		// Global keys
 		switch msg.String() {
 		case "ctrl+c":
-			a.watcher.close()
-			return a, tea.Quit
+			return a, a.quit()
 		case "q":
-			if a.showHelp {
-				a.showHelp = false
+			if a.showHelp || a.showDebug || a.showErrors {
+				a.closeOverlays()
 				return a, nil
 			}
-			if a.viewMode == ViewDetail {
+			if a.textInputActive() {
+				break // let the view's text input handle it
+			}
+			switch a.viewMode {
+			case ViewDetail:
 				a.popDetail()
 				return a, nil
-			}
-			if a.list.IsFiltering() {
-				// let list handle it
-			} else {
-				a.watcher.close()
-				return a, tea.Quit
+			case ViewList:
+				return a, a.quit()
+			case ViewCritical:
+				a.closeCritical()
+				return a, nil
+			case ViewMetrics:
+				a.viewMode = a.metricsReturn
+				return a, nil
+			case ViewHistory:
+				a.closeHistory()
+				return a, nil
+			default:
+				a.viewMode = ViewList
+				return a, nil
 			}
 		case "?":
 			a.showHelp = !a.showHelp
@@ -212,38 +649,206 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// If help is showing, close it on any other key
-		if a.showHelp {
-			a.showHelp = false
+		// If an overlay is showing, close it on any other key
+		if a.showHelp || a.showDebug || a.showErrors {
+			a.closeOverlays()
 			return a, nil
 		}
 
+		// History navigation and the overlays work from every view.
+		if !a.textInputActive() {
+			switch msg.String() {
+			case "D":
+				return a, a.openDebug()
+			case "E":
+				return a, a.openErrors()
+			case "H", "alt+left":
+				return a, a.goBack()
+			case "L", "alt+right":
+				return a, a.goForward()
+			case "R":
+				a.openHistory()
+				return a, nil
+			case "B":
+				return a, a.openStarred()
+			}
+		}
+
 		// View-specific handling
 		switch a.viewMode {
 		case ViewList:
 			return a.updateList(msg)
 		case ViewDetail:
 			return a.updateDetail(msg)
+		case ViewActivity:
+			return a.updateActivity(msg)
+		case ViewNotifications:
+			return a.updateNotifications(msg)
+		case ViewNext:
+			return a.updateNext(msg)
+		case ViewCritical:
+			return a.updateCritical(msg)
+		case ViewMetrics:
+			return a.updateMetrics(msg)
+		case ViewAgenda:
+			return a.updateAgenda(msg)
+		case ViewSearch:
+			return a.updateSearch(msg)
+		case ViewHistory:
+			return a.updateHistory(msg)
 		}
 	}
 
 	// Pass through to active view for non-key messages (e.g., blink)
-	if a.viewMode == ViewList {
+	switch a.viewMode {
+	case ViewList:
 		cmd := a.list.Update(msg)
 		return a, cmd
+	case ViewDetail:
+		if a.detail != nil {
+			return a, a.detail.Update(msg)
+		}
+	case ViewActivity:
+		cmd := a.activity.Update(msg)
+		return a, cmd
+	case ViewNotifications:
+		cmd := a.notifs.Update(msg)
+		return a, cmd
+	case ViewSearch:
+		cmd := a.search.Update(msg)
+		return a, cmd
 	}
 
 	return a, nil
 }
 
+// dataChanged updates everything derived from the issue data after a full
+// or incremental load, given the changes the list detected.
+func (a *App) dataChanged(changes []models.IssueChange) tea.Cmd {
+	a.activity.Record(changes)
+	a.rankNext()
+	a.agenda.SetData(a.issues)
+	a.search.SetData(a.issues)
+	a.historyView.SetData(a.issues)
+	if a.critical != nil {
+		if analysis := graph.AnalyzeEpic(a.critical.EpicID(), a.issues); analysis != nil {
+			a.critical.SetAnalysis(analysis, a.avgLeadHours())
+		}
+	}
+	if a.viewMode == ViewMetrics {
+		a.refreshMetrics()
+	}
+
+	cmds := []tea.Cmd{a.refreshPreview()}
+	if a.indexEnabled {
+		cmds = append(cmds, a.syncIndex())
+	}
+	if len(changes) > 0 {
+		cmds = append(cmds, tea.Tick(views.FlashDuration(), func(t time.Time) tea.Msg {
+			return views.FlashExpiredMsg{}
+		}))
+	}
+	snap := notify.NewSnapshot(a.issues, a.readyIssues)
+	notes := notify.Evaluate(a.cfg.Watches, a.watchSnap, snap, changes)
+	a.watchSnap = snap
+	if len(notes) > 0 {
+		a.notifs.Add(notes)
+		if a.viewMode == ViewNotifications {
+			a.notifs.MarkRead()
+		}
+		a.list.SetUnreadNotifications(a.notifs.Unread())
+		cmds = append(cmds, a.sendNotifications(notes))
+	}
+	return tea.Batch(cmds...)
+}
+
 func (a *App) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		if !a.list.IsFiltering() {
 			if issue := a.list.SelectedIssue(); issue != nil {
-				a.loading = true
-				return a, a.loadDetail(issue.ID)
+				a.recordVisit()
+				a.detailReturn = ViewList
+				return a, a.openDetail(issue.ID)
+			}
+		}
+	case "*":
+		if !a.list.IsFiltering() {
+			if issue := a.list.SelectedIssue(); issue != nil {
+				return a, a.toggleStar(issue.ID)
+			}
+			return a, nil
+		}
+	case "a":
+		if !a.list.IsFiltering() {
+			a.viewMode = ViewActivity
+			return a, nil
+		}
+	case "n":
+		if !a.list.IsFiltering() {
+			a.rankNext()
+			a.next.ResetCursor()
+			a.viewMode = ViewNext
+			return a, nil
+		}
+	case "N":
+		if !a.list.IsFiltering() {
+			a.openNotifications()
+			return a, nil
+		}
+	case "w":
+		if !a.list.IsFiltering() {
+			if issue := a.list.SelectedIssue(); issue != nil {
+				return a, a.toggleWatch(issue.ID)
+			}
+			return a, nil
+		}
+	case "p":
+		if !a.list.IsFiltering() {
+			if issue := a.list.SelectedIssue(); issue != nil {
+				return a, a.openCritical(issue.ID)
+			}
+			return a, nil
+		}
+	case "C":
+		if !a.list.IsFiltering() {
+			a.viewMode = ViewAgenda
+			return a, nil
+		}
+	case "f":
+		if !a.list.IsFiltering() {
+			return a, a.openSearch()
+		}
+	case "v":
+		if !a.list.IsFiltering() {
+			return a, a.toggleSplit()
+		}
+	case "<", ">":
+		if a.split && !a.list.IsFiltering() {
+			step := splitRatioStep
+			if msg.String() == "<" {
+				step = -step
+			}
+			a.resizeSplit(step)
+			return a, nil
+		}
+	case "J", "K":
+		if a.split && a.preview != nil && !a.list.IsFiltering() {
+			delta := 3
+			if msg.String() == "K" {
+				delta = -3
+			}
+			a.preview.ScrollBy(delta)
+			return a, nil
+		}
+	case "m":
+		if !a.list.IsFiltering() {
+			epic := ""
+			if issue := a.list.SelectedIssue(); issue != nil {
+				epic = issue.ID
 			}
+			a.openMetrics(metricsScopeFilter, epic)
+			return a, nil
 		}
 	case "r":
 		if !a.list.IsFiltering() {
@@ -262,13 +867,25 @@ func (a *App) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	before, wasFiltering := a.list.State(), a.list.IsFiltering()
 	cmd := a.list.Update(msg)
-	return a, cmd
+	a.recordListChange(before, wasFiltering)
+	cmds := []tea.Cmd{cmd, a.schedulePreview()}
+	if issue := a.list.SelectedIssue(); issue != nil && issue.ID != before.SelectedID {
+		cmds = append(cmds, a.schedulePrefetch())
+	}
+	return a, tea.Batch(cmds...)
 }
 
 func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.detail != nil && (a.detail.IsSearching() || a.detail.IsEditingNote()) {
+		return a, a.detail.Update(msg)
+	}
 	switch msg.String() {
 	case "esc":
+		if a.detail != nil && a.detail.ClearSearch() {
+			return a, nil
+		}
 		a.popDetail()
 		return a, nil
 	case "r":
@@ -276,6 +893,35 @@ func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.loading = true
 			return a, a.loadDetail(a.detail.IssueID())
 		}
+	case "w":
+		if a.detail != nil {
+			return a, a.toggleWatch(a.detail.IssueID())
+		}
+		return a, nil
+	case "*":
+		if a.detail != nil {
+			return a, a.toggleStar(a.detail.IssueID())
+		}
+		return a, nil
+	case "p":
+		if a.detail != nil {
+			return a, a.openCritical(a.detail.IssueID())
+		}
+		return a, nil
+	case "m":
+		if a.detail != nil {
+			a.openMetrics(metricsScopeEpic, a.detail.IssueID())
+		}
+		return a, nil
+	case "M":
+		a.rawMarkdown = !a.rawMarkdown
+		if a.detail != nil {
+			a.detail.SetRawMarkdown(a.rawMarkdown)
+		}
+		if a.preview != nil {
+			a.preview.SetRawMarkdown(a.rawMarkdown)
+		}
+		return a, nil
 	case "y":
 		if a.detail != nil {
 			if copyToClipboard(a.detail.IssueID()) {
@@ -293,13 +939,352 @@ func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+func (a *App) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Esc leaves the view unless it just closes a non-empty query input.
+		if !a.search.IsTyping() || a.search.Query() == "" {
+			a.viewMode = ViewList
+			return a, nil
+		}
+	case "r":
+		if !a.search.IsTyping() {
+			a.loading = true
+			return a, a.loadData()
+		}
+	}
+	cmd := a.search.Update(msg)
+	return a, cmd
+}
+
+func (a *App) updateActivity(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !a.activity.IsFiltering() {
+		switch msg.String() {
+		case "esc":
+			a.viewMode = ViewList
+			return a, nil
+		case "r":
+			a.loading = true
+			return a, a.loadData()
+		case "y":
+			if e := a.activity.SelectedEntry(); e != nil {
+				if copyToClipboard(e.IssueID) {
+					return a, a.setStatus(fmt.Sprintf("copied %s", e.IssueID))
+				}
+				return a, a.setStatus("clipboard not available on this platform")
+			}
+			return a, nil
+		}
+	}
+
+	cmd := a.activity.Update(msg)
+	return a, cmd
+}
+
+func (a *App) updateNotifications(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !a.notifs.IsFiltering() && msg.String() == "esc" {
+		a.viewMode = ViewList
+		return a, nil
+	}
+	cmd := a.notifs.Update(msg)
+	return a, cmd
+}
+
+func (a *App) updateNext(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.viewMode = ViewList
+		return a, nil
+	case "r":
+		a.loading = true
+		return a, a.loadData()
+	}
+	cmd := a.next.Update(msg)
+	return a, cmd
+}
+
+func (a *App) updateCritical(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.closeCritical()
+		return a, nil
+	case "r":
+		a.loading = true
+		return a, a.loadData()
+	}
+	cmd := a.critical.Update(msg)
+	return a, cmd
+}
+
+// openCritical analyzes an epic and shows its critical path view. When
+// opened from the detail view, the detail chain is saved for closeCritical.
+func (a *App) openCritical(id string) tea.Cmd {
+	analysis := graph.AnalyzeEpic(id, a.issues)
+	if analysis == nil {
+		return a.setStatus(fmt.Sprintf("%s not found in the issue list", id))
+	}
+	if analysis.Total == 0 {
+		return a.setStatus(fmt.Sprintf("%s has no children to analyze", id))
+	}
+	a.criticalReturn = a.viewMode
+	a.criticalSaved = nil
+	if a.viewMode == ViewDetail {
+		a.criticalSaved = &detailState{detail: a.detail, stack: a.detailStack, ret: a.detailReturn}
+	}
+	a.critical = views.NewCriticalPathView(analysis, a.avgLeadHours())
+	a.critical.SetSize(a.width, a.height)
+	a.viewMode = ViewCritical
+	return nil
+}
+
+// closeCritical returns to wherever the critical path view was opened from.
+func (a *App) closeCritical() {
+	if s := a.criticalSaved; s != nil {
+		a.detail, a.detailStack, a.detailReturn = s.detail, s.stack, s.ret
+	}
+	a.critical = nil
+	a.criticalSaved = nil
+	a.viewMode = a.criticalReturn
+}
+
+func (a *App) updateAgenda(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if !a.agenda.Back() {
+			a.viewMode = ViewList
+		}
+		return a, nil
+	case "r":
+		a.loading = true
+		return a, a.loadData()
+	}
+	cmd := a.agenda.Update(msg)
+	return a, cmd
+}
+
+func (a *App) updateMetrics(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.viewMode = a.metricsReturn
+		return a, nil
+	case "r":
+		a.loading = true
+		return a, a.loadData()
+	}
+	cmd := a.metrics.Update(msg)
+	return a, cmd
+}
+
+// openMetrics shows the metrics view. epic is the issue the epic scope
+// charts; it is only kept if the issue has children.
+func (a *App) openMetrics(scope metricsScope, epic string) {
+	a.metricsEpic = ""
+	if epic != "" && len(graph.Descendants(epic, a.issues)) > 0 {
+		a.metricsEpic = epic
+	}
+	a.metricsScope = scope
+	if scope == metricsScopeEpic && a.metricsEpic == "" {
+		a.metricsScope = metricsScopeFilter
+	}
+	a.metricsReturn = a.viewMode
+	a.refreshMetrics()
+	a.viewMode = ViewMetrics
+}
+
+// refreshMetrics recomputes the metrics view's issues for its scope.
+func (a *App) refreshMetrics() {
+	switch a.metricsScope {
+	case metricsScopeAll:
+		a.metrics.SetScope(a.issues, "all issues")
+	case metricsScopeEpic:
+		a.metrics.SetScope(graph.Descendants(a.metricsEpic, a.issues), "epic "+a.metricsEpic)
+	default:
+		issues, desc := a.list.FilterScope()
+		a.metrics.SetScope(issues, "filter "+desc)
+	}
+}
+
+// avgLeadHours returns the average lead time from bd stats, or 0.
+func (a *App) avgLeadHours() float64 {
+	if a.stats == nil {
+		return 0
+	}
+	return a.stats.AvgLeadTimeHours
+}
+
+// rankNext recomputes the next-task picker from the latest data load.
+func (a *App) rankNext() {
+	ranked := triage.Rank(a.readyIssues, a.issues, a.cfg.Next.Weights, a.me, time.Now())
+	a.next.SetData(ranked, a.me)
+}
+
+// claim sets an issue in_progress and assigns it to the current user.
+func (a *App) claim(id string) tea.Cmd {
+	me := a.me
+	return func() tea.Msg {
+		return claimDoneMsg{id: id, err: a.client.Claim(id, me)}
+	}
+}
+
+// openSearch switches to full-text search, building the index on first use.
+func (a *App) openSearch() tea.Cmd {
+	a.viewMode = ViewSearch
+	cmd := a.search.Open()
+	if !a.indexEnabled {
+		a.indexEnabled = true
+		return tea.Batch(cmd, a.syncIndex())
+	}
+	return cmd
+}
+
+// syncIndex queues issues that are missing from the search index or have
+// changed since they were indexed, and starts fetching them. Issues in
+// the batch being fetched aren't queued again, nor are ones that failed
+// to fetch and haven't changed since.
+func (a *App) syncIndex() tea.Cmd {
+	updated := updatedTimes(a.issues)
+	for id, at := range a.indexFailed {
+		if cur, ok := updated[id]; !ok || !cur.Equal(at) {
+			delete(a.indexFailed, id)
+		}
+	}
+	a.indexQueue = nil
+	for _, id := range a.index.Sync(a.issues) {
+		if _, failed := a.indexFailed[id]; !failed && !a.indexInFlight[id] {
+			a.indexQueue = append(a.indexQueue, id)
+		}
+	}
+	a.updateIndexProgress()
+	if len(a.indexInFlight) > 0 {
+		return nil // the in-flight batch picks up the queue when it lands
+	}
+	return a.fetchIndexBatch()
+}
+
+// noteIndexFailures remembers the issues of a batch that bd show didn't
+// return, so they aren't fetched again until their UpdatedAt moves.
+func (a *App) noteIndexFailures(msg indexBatchMsg) {
+	got := make(map[string]bool, len(msg.issues))
+	for i := range msg.issues {
+		got[msg.issues[i].ID] = true
+	}
+	updated := updatedTimes(a.issues)
+	for _, id := range msg.ids {
+		if !got[id] {
+			a.indexFailed[id] = updated[id]
+		}
+	}
+}
+
+// fetchIndexBatch fetches the next batch of queued issue bodies. If bd
+// show rejects the batch, the issues are fetched one at a time so a single
+// bad ID doesn't stall indexing.
+func (a *App) fetchIndexBatch() tea.Cmd {
+	if len(a.indexQueue) == 0 {
+		a.updateIndexProgress()
+		return nil
+	}
+	n := min(indexBatchSize, len(a.indexQueue))
+	ids := a.indexQueue[:n:n]
+	a.indexQueue = a.indexQueue[n:]
+	for _, id := range ids {
+		a.indexInFlight[id] = true
+	}
+	a.updateIndexProgress()
+	return func() tea.Msg {
+		issues, err := a.client.ShowMany(ids)
+		if err == nil {
+			return indexBatchMsg{ids: ids, issues: issues}
+		}
+		issues = nil
+		for _, id := range ids {
+			issue, ierr := a.client.Show(id)
+			if ierr != nil {
+				continue
+			}
+			issues = append(issues, *issue)
+		}
+		if len(issues) == 0 {
+			return indexBatchMsg{ids: ids, err: err}
+		}
+		return indexBatchMsg{ids: ids, issues: issues}
+	}
+}
+
+// updateIndexProgress tells the search view how much is indexed. Issues
+// that failed to fetch don't count, so progress can still complete.
+func (a *App) updateIndexProgress() {
+	pending := len(a.indexQueue) + len(a.indexInFlight)
+	total := max(0, len(a.issues)-len(a.indexFailed))
+	a.search.SetProgress(max(0, total-pending), total)
+}
+
+// textInputActive reports whether the active view is capturing text, in
+// which case global single-letter keys must pass through to it.
+func (a *App) textInputActive() bool {
+	switch a.viewMode {
+	case ViewList:
+		return a.list.IsFiltering()
+	case ViewActivity:
+		return a.activity.IsFiltering()
+	case ViewNotifications:
+		return a.notifs.IsFiltering()
+	case ViewDetail:
+		return a.detail != nil && (a.detail.IsSearching() || a.detail.IsEditingNote())
+	case ViewSearch:
+		return a.search.IsTyping()
+	}
+	return false
+}
+
+// openNotifications switches to the notification center and marks
+// everything in it as read.
+func (a *App) openNotifications() {
+	a.notifs.MarkRead()
+	a.list.SetUnreadNotifications(0)
+	a.viewMode = ViewNotifications
+}
+
+// toggleWatch adds or removes an issue watch and persists the config.
+func (a *App) toggleWatch(id string) tea.Cmd {
+	if a.cfg.ToggleIssueWatch(id) {
+		return a.saveWatches(fmt.Sprintf("watching %s", id))
+	}
+	return a.saveWatches(fmt.Sprintf("unwatched %s", id))
+}
+
+// saveWatches persists the watch list and refreshes the notification
+// center, reporting status (or the save error) in the status bar.
+func (a *App) saveWatches(status string) tea.Cmd {
+	a.notifs.SetWatches(a.cfg.Watches)
+	if err := a.cfg.Save(); err != nil {
+		return a.setStatus(fmt.Sprintf("saving config: %s", err))
+	}
+	return a.setStatus(status)
+}
+
+// sendNotifications delivers notifications in the background.
+func (a *App) sendNotifications(notes []notify.Notification) tea.Cmd {
+	n := a.notifier
+	return func() tea.Msg {
+		var firstErr error
+		for _, note := range notes {
+			if err := n.Send(note); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return notifySentMsg{err: firstErr}
+	}
+}
+
 func (a *App) popDetail() {
+	a.recordVisit()
 	if len(a.detailStack) > 0 {
 		a.detail = a.detailStack[len(a.detailStack)-1]
 		a.detailStack = a.detailStack[:len(a.detailStack)-1]
 	} else {
 		a.detail = nil
-		a.viewMode = ViewList
+		a.viewMode = a.detailReturn
 	}
 }
 
@@ -321,6 +1306,12 @@ func (a *App) View() string {
 	if a.showHelp {
 		return a.help.View()
 	}
+	if a.showDebug {
+		return a.debugView.View()
+	}
+	if a.showErrors {
+		return a.errorView.View()
+	}
 
 	if a.err != nil {
 		errMsg := errorView(a.err, a.width, a.height)
@@ -336,8 +1327,29 @@ func (a *App) View() string {
 		if a.detail != nil {
 			return a.detail.View()
 		}
+	case ViewActivity:
+		return a.activity.View()
+	case ViewNotifications:
+		return a.notifs.View()
+	case ViewNext:
+		return a.next.View()
+	case ViewCritical:
+		if a.critical != nil {
+			return a.critical.View()
+		}
+	case ViewMetrics:
+		return a.metrics.View()
+	case ViewAgenda:
+		return a.agenda.View()
+	case ViewSearch:
+		return a.search.View()
+	case ViewHistory:
+		return a.historyView.View()
 	}
 
+	if a.split {
+		return a.renderSplit()
+	}
 	return a.list.View()
 }
 
@@ -351,9 +1363,10 @@ func (a *App) loadDataQuiet() tea.Cmd {
 
 func (a *App) loadDataWithOpts(quiet bool) tea.Cmd {
 	return func() tea.Msg {
+		start := time.Now()
 		issues, err := a.client.ListAll()
 		if err != nil {
-			return dataLoadedMsg{err: err, quiet: quiet}
+			return dataLoadedMsg{err: err, quiet: quiet, took: time.Since(start)}
 		}
 
 		// Ready and stats are non-fatal if they fail
@@ -365,10 +1378,23 @@ func (a *App) loadDataWithOpts(quiet bool) tea.Cmd {
 			readyIssues: readyIssues,
 			stats:       stats,
 			quiet:       quiet,
+			took:        time.Since(start),
 		}
 	}
 }
 
+// autoRefresh reloads data in the background without the loading screen,
+// incrementally where possible (see delta.go). The list data is always
+// reloaded so the activity feed and watch notifications stay live
+// regardless of the active view.
+func (a *App) autoRefresh() tea.Cmd {
+	cmds := []tea.Cmd{a.refreshData()}
+	if a.viewMode == ViewDetail && a.detail != nil {
+		cmds = append(cmds, a.loadDetailQuiet(a.detail.IssueID()))
+	}
+	return tea.Batch(cmds...)
+}
+
 func (a *App) loadDetail(id string) tea.Cmd {
 	return a.loadDetailWithOpts(id, false)
 }
@@ -384,9 +1410,26 @@ func (a *App) loadDetailWithOpts(id string, quiet bool) tea.Cmd {
 	}
 }
 
+// quit stops the watcher, saves pending local state and exits.
+func (a *App) quit() tea.Cmd {
+	a.watcher.close()
+	a.flushRecent()
+	return tea.Quit
+}
+
 func (a *App) setStatus(msg string) tea.Cmd {
 	a.statusMsg = msg
 	a.list.SetStatusMsg(msg)
+	a.activity.SetStatusMsg(msg)
+	a.notifs.SetStatusMsg(msg)
+	a.next.SetStatusMsg(msg)
+	a.metrics.SetStatusMsg(msg)
+	a.agenda.SetStatusMsg(msg)
+	a.search.SetStatusMsg(msg)
+	a.historyView.SetStatusMsg(msg)
+	if a.critical != nil {
+		a.critical.SetStatusMsg(msg)
+	}
 	if a.detail != nil {
 		a.detail.SetStatusMsg(msg)
 	}
@@ -395,6 +1438,13 @@ func (a *App) setStatus(msg string) tea.Cmd {
 	})
 }
 
+// firstLine returns the first line of s, for fitting multi-line bd errors
+// into the status bar.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
 // copyToClipboard copies text to the system clipboard.
 // Returns true if a clipboard command was available, false otherwise.
 func copyToClipboard(text string) bool {
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"container/list"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/views"
+)
+
+// issueCacheSize is how many full issues the cache keeps.
+const issueCacheSize = 256
+
+// issueCache is an LRU cache of full issues from bd show, keyed by ID.
+// Entries are dropped when a data load reports a different UpdatedAt (see
+// invalidate), so anything it returns is as current as the issue list.
+type issueCache struct {
+	capacity int
+	order    *list.List               // front = most recently used
+	entries  map[string]*list.Element // values are *models.Issue
+}
+
+func newIssueCache(capacity int) *issueCache {
+	return &issueCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns a cached issue and marks it recently used.
+func (c *issueCache) get(id string) (*models.Issue, bool) {
+	el, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*models.Issue), true
+}
+
+// has reports whether id is cached without affecting its recency.
+func (c *issueCache) has(id string) bool {
+	_, ok := c.entries[id]
+	return ok
+}
+
+// put stores a full issue, evicting the least recently used entry when
+// the cache is full.
+func (c *issueCache) put(issue *models.Issue) {
+	if el, ok := c.entries[issue.ID]; ok {
+		el.Value = issue
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[issue.ID] = c.order.PushFront(issue)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*models.Issue).ID)
+	}
+}
+
+// invalidate drops entries whose issue is gone from the list or whose
+// UpdatedAt differs from the list's.
+func (c *issueCache) invalidate(updated map[string]time.Time) {
+	for id, el := range c.entries {
+		if at, ok := updated[id]; !ok || !el.Value.(*models.Issue).UpdatedAt.Equal(at) {
+			c.order.Remove(el)
+			delete(c.entries, id)
+		}
+	}
+}
+
+// Prefetching keeps likely next stops in the cache: the issues linked from
+// the open detail and the rows around the list cursor.
+const (
+	// prefetchDelay debounces neighbour prefetch while the cursor moves.
+	prefetchDelay = 300 * time.Millisecond
+
+	// prefetchRadius is how many rows above and below the cursor are
+	// prefetched.
+	prefetchRadius = 2
+)
+
+// prefetchTickMsg fires when the list cursor has rested for prefetchDelay.
+// Stale ticks (seq != App.prefetchSeq) are ignored.
+type prefetchTickMsg struct {
+	seq int
+}
+
+// prefetchedMsg delivers issues fetched in the background for the cache.
+type prefetchedMsg struct {
+	ids    []string
+	issues []models.Issue
+}
+
+// linkedIDs returns the IDs an issue's detail view links to: parent,
+// dependencies and dependents.
+func linkedIDs(issue *models.Issue) []string {
+	var ids []string
+	if issue.Parent != nil && *issue.Parent != "" {
+		ids = append(ids, *issue.Parent)
+	}
+	for _, d := range issue.Dependencies {
+		ids = append(ids, d.ParentID())
+	}
+	for _, d := range issue.Dependents {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+// prefetch fetches the given issues into the cache in the background,
+// skipping ones already cached or in flight.
+func (a *App) prefetch(ids []string) tea.Cmd {
+	var want []string
+	for _, id := range ids {
+		if id == "" || a.cache.has(id) || a.prefetching[id] {
+			continue
+		}
+		a.prefetching[id] = true
+		want = append(want, id)
+	}
+	if len(want) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		issues, err := a.client.ShowMany(want)
+		if err != nil {
+			// One missing issue fails the whole batch; fetch the rest
+			// one by one.
+			issues = nil
+			for _, id := range want {
+				if issue, err := a.client.Show(id); err == nil {
+					issues = append(issues, *issue)
+				}
+			}
+		}
+		return prefetchedMsg{ids: want, issues: issues}
+	}
+}
+
+// prefetchLinks prefetches the issues the detail for issue links to.
+func (a *App) prefetchLinks(issue *models.Issue) tea.Cmd {
+	return a.prefetch(linkedIDs(issue))
+}
+
+// schedulePrefetch debounces prefetching the rows around the list cursor.
+func (a *App) schedulePrefetch() tea.Cmd {
+	a.prefetchSeq++
+	seq := a.prefetchSeq
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchTickMsg{seq: seq}
+	})
+}
+
+// openDetail opens id in the detail view. A cached issue is shown at once
+// and refreshed quietly; otherwise the loading screen covers bd show.
+func (a *App) openDetail(id string) tea.Cmd {
+	issue, ok := a.cache.get(id)
+	if !ok {
+		a.loading = true
+		return a.loadDetail(id)
+	}
+	a.showDetail(issue)
+	return tea.Batch(a.loadDetailQuiet(id), a.prefetchLinks(issue))
+}
+
+// showDetail replaces the current detail view with one for issue.
+func (a *App) showDetail(issue *models.Issue) {
+	a.detail = views.NewDetailView(issue)
+	a.detail.SetRawMarkdown(a.rawMarkdown)
+	a.detail.SetSize(a.width, a.height)
+	a.detail.SetBreadcrumbs(a.breadcrumbTrail())
+	a.applyStar(a.detail)
+	a.viewMode = ViewDetail
+	a.noteRecent(issue)
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+	"github.com/poiley/beady/internal/views"
+)
+
+// Checklist toggles are saved with bd update --acceptance, which rewrites
+// the whole field. Saves of one issue run one at a time, so a quick second
+// toggle can't be overwritten by the first finishing late, and each save
+// re-applies its toggle to the criteria as bd has them right then, so an
+// edit made elsewhere in the meantime isn't lost. The detail view is only
+// reloaded once the queue has drained, so it doesn't revert toggles that
+// are still waiting.
+
+// checklistSavedMsg reports the result of saving a checklist toggle.
+type checklistSavedMsg struct {
+	id   string
+	item string
+	done bool
+	err  error
+}
+
+// queueChecklist saves a toggle now, or after the issue's running save.
+func (a *App) queueChecklist(msg views.ToggleChecklistMsg) tea.Cmd {
+	if queue, busy := a.checklistQueue[msg.ID]; busy {
+		a.checklistQueue[msg.ID] = append(queue, msg)
+		return nil
+	}
+	a.checklistQueue[msg.ID] = nil
+	return a.saveChecklist(msg)
+}
+
+// saveChecklist fetches the issue's current acceptance criteria, applies
+// the toggle to them and writes them back through bd.
+func (a *App) saveChecklist(msg views.ToggleChecklistMsg) tea.Cmd {
+	return func() tea.Msg {
+		saved := checklistSavedMsg{id: msg.ID, item: msg.Item, done: msg.Done}
+		issue, err := a.client.Show(msg.ID)
+		if err != nil {
+			saved.err = err
+			return saved
+		}
+		criteria, changed, err := applyToggle(issue.AcceptanceCriteria, msg)
+		if err == nil && changed {
+			err = a.client.UpdateAcceptance(msg.ID, criteria)
+		}
+		saved.err = err
+		return saved
+	}
+}
+
+// applyToggle sets the checklist item msg refers to in criteria. The item
+// is looked up on its line, or by its text if lines moved; it reports
+// changed false if the item is already in the wanted state.
+func applyToggle(criteria string, msg views.ToggleChecklistMsg) (string, bool, error) {
+	var match *models.ChecklistItem
+	var byText []models.ChecklistItem
+	for _, it := range models.ParseChecklist(criteria) {
+		if it.Text != msg.Item {
+			continue
+		}
+		if it.Line == msg.Line {
+			match = &it
+			break
+		}
+		byText = append(byText, it)
+	}
+	if match == nil {
+		if len(byText) != 1 {
+			return criteria, false, fmt.Errorf("acceptance criteria changed since they were loaded; %q not saved", msg.Item)
+		}
+		match = &byText[0]
+	}
+	if match.Done == msg.Done {
+		return criteria, false, nil
+	}
+	criteria, _ = models.ToggleChecklistItem(criteria, match.Line)
+	return criteria, true, nil
+}
+
+// checklistSaved reports a finished save and starts the issue's next
+// queued toggle, or, once none are left, reloads the issue and the list.
+func (a *App) checklistSaved(msg checklistSavedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	if msg.err != nil {
+		cmds = append(cmds, a.setStatus(fmt.Sprintf("update %s failed: %s", msg.id, firstLine(msg.err.Error()))))
+	} else {
+		verb := "unchecked"
+		if msg.done {
+			verb = "checked"
+		}
+		cmds = append(cmds, a.setStatus(fmt.Sprintf("%s %q", verb, ui.Truncate(msg.item, 40))))
+	}
+
+	if queue := a.checklistQueue[msg.id]; len(queue) > 0 {
+		a.checklistQueue[msg.id] = queue[1:]
+		return tea.Batch(append(cmds, a.saveChecklist(queue[0]))...)
+	}
+	delete(a.checklistQueue, msg.id)
+	// Reloading the detail also reverts any toggle that failed to save.
+	if a.viewMode == ViewDetail && a.detail != nil && a.detail.IssueID() == msg.id {
+		cmds = append(cmds, a.loadDetailQuiet(msg.id))
+	}
+	return tea.Batch(append(cmds, a.loadDataQuiet())...)
+}
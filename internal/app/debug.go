@@ -0,0 +1,193 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/views"
+)
+
+// The diagnostics overlay (D) shows how auto-refresh is behaving: how
+// often the data reloads and how long bd takes, what the watcher is doing,
+// and the latest bd commands and errors from the diagnostics recorder.
+
+const (
+	// debugTickInterval is how often the open overlay updates its figures.
+	debugTickInterval = time.Second
+
+	// Limits on the recorder's history shown in the overlay.
+	debugCommands = 8
+	debugErrors   = 4
+
+	// slowUpdate is how long handling a message may take before it is
+	// logged. Data loads are always logged.
+	slowUpdate = 20 * time.Millisecond
+)
+
+// isLoadMsg reports whether msg delivers data from bd.
+func isLoadMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case dataLoadedMsg, deltaLoadedMsg, detailLoadedMsg, fileChangedMsg:
+		return true
+	}
+	return false
+}
+
+// debugTickMsg refreshes the debug overlay while it is open.
+type debugTickMsg struct{}
+
+// refreshStats counts data loads and their latency.
+type refreshStats struct {
+	loads    int
+	quiet    int // loads triggered by auto-refresh or focus
+	deltas   int // incremental loads (see delta.go)
+	failures int
+	last     time.Duration
+	total    time.Duration
+	slowest  time.Duration
+	lastAt   time.Time
+}
+
+// recordDelta adds a finished incremental load.
+func (s *refreshStats) recordDelta(took time.Duration, err error) {
+	s.deltas++
+	s.record(took, true, err)
+}
+
+// record adds a finished load.
+func (s *refreshStats) record(took time.Duration, quiet bool, err error) {
+	s.loads++
+	if quiet {
+		s.quiet++
+	}
+	if err != nil {
+		s.failures++
+	}
+	s.last = took
+	s.total += took
+	s.slowest = max(s.slowest, took)
+	s.lastAt = time.Now()
+}
+
+// closeOverlays hides the help, diagnostics and error overlays.
+func (a *App) closeOverlays() {
+	a.showHelp = false
+	a.showDebug = false
+	a.showErrors = false
+}
+
+// openDebug shows the debug overlay.
+func (a *App) openDebug() tea.Cmd {
+	a.showDebug = true
+	a.debugView.SetSections(a.debugSections())
+	return a.debugTick()
+}
+
+func (a *App) debugTick() tea.Cmd {
+	return tea.Tick(debugTickInterval, func(time.Time) tea.Msg { return debugTickMsg{} })
+}
+
+// debugSections gathers the overlay's figures.
+func (a *App) debugSections() []views.DebugSection {
+	s := a.refreshStats
+	avg, lastAt := "-", "never"
+	if s.loads > 0 {
+		avg = roundDuration(s.total / time.Duration(s.loads))
+		lastAt = roundDuration(time.Since(s.lastAt)) + " ago"
+	}
+	lastFull := "never"
+	if !a.delta.lastFull.IsZero() {
+		lastFull = roundDuration(time.Since(a.delta.lastFull)) + " ago"
+	}
+	incremental := "on"
+	if !a.delta.supported {
+		incremental = "off (bd lacks --updated-after)"
+	}
+	logFile := a.diag.Path()
+	if logFile == "" {
+		logFile = "off (--log-file or BDY_LOG)"
+	}
+	focus := "focused"
+	if !a.focused {
+		focus = "unfocused"
+	}
+
+	w := a.watcher.snapshot()
+	polling := "every " + roundDuration(w.interval)
+	switch {
+	case a.watcher.health == views.WatchDisabled:
+		polling = "off"
+	case w.paused:
+		polling = "paused (terminal unfocused)"
+	}
+
+	sections := []views.DebugSection{
+		{Title: "Refresh", Rows: []views.DebugRow{
+			{Label: "loads", Value: fmt.Sprintf("%d (%d automatic, %d incremental)", s.loads, s.quiet, s.deltas)},
+			{Label: "failures", Value: fmt.Sprint(s.failures)},
+			{Label: "latency", Value: fmt.Sprintf("last %s, avg %s, max %s", roundDuration(s.last), avg, roundDuration(s.slowest))},
+			{Label: "last load", Value: lastAt},
+			{Label: "full resync", Value: lastFull},
+			{Label: "incremental", Value: incremental},
+			{Label: "terminal", Value: focus},
+			{Label: "log file", Value: logFile},
+		}},
+		{Title: "Watcher", Rows: []views.DebugRow{
+			{Label: "health", Value: a.watcher.health.String()},
+			{Label: "source", Value: a.watcher.reason},
+			{Label: "polling", Value: polling},
+			{Label: "polls", Value: fmt.Sprintf("%d (%d caught a change)", w.polls, w.pollHits)},
+			{Label: "file events", Value: fmt.Sprint(w.fileEvents)},
+			{Label: "changes", Value: fmt.Sprint(w.changes)},
+		}},
+	}
+
+	cmds := views.DebugSection{Title: "Recent bd commands"}
+	for i, c := range a.diag.Commands() {
+		if i == debugCommands {
+			break
+		}
+		value := strings.Join(c.Args, " ")
+		if c.Err != nil {
+			value = fmt.Sprintf("%s (exit %d)", value, c.ExitCode)
+		}
+		cmds.Rows = append(cmds.Rows, views.DebugRow{
+			Label: c.Start.Format("15:04:05") + " " + roundDuration(c.Duration),
+			Value: value,
+		})
+	}
+	if len(cmds.Rows) == 0 {
+		cmds.Rows = append(cmds.Rows, views.DebugRow{Label: "-", Value: "none yet"})
+	}
+
+	errs := views.DebugSection{Title: "Errors"}
+	for i, e := range a.diag.Errors() {
+		if i == debugErrors {
+			break
+		}
+		errs.Rows = append(errs.Rows, views.DebugRow{
+			Label: e.At.Format("15:04:05") + " " + e.Source,
+			Value: firstLine(e.Err.Error()),
+		})
+	}
+	if len(errs.Rows) == 0 {
+		errs.Rows = append(errs.Rows, views.DebugRow{Label: "-", Value: "none"})
+	}
+
+	return append(sections, cmds, errs)
+}
+
+// roundDuration formats d to a sensible precision for display.
+func roundDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
@@ -0,0 +1,284 @@
+package app
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/bd"
+	"github.com/poiley/beady/internal/models"
+)
+
+// Incremental refresh. A full load runs bd list, ready and stats; on a
+// large database that takes seconds, too long to repeat for every comment.
+// Auto-refreshes instead ask bd list for the issues updated since the
+// newest UpdatedAt seen and merge them in, working out readiness and the
+// header stats for just the issues affected. bd doesn't report deletions
+// and its readiness rules are richer than the local approximation, so a
+// full load still runs every fullResyncInterval to reconcile.
+
+// fullResyncInterval is the longest bdy goes without a full load.
+const fullResyncInterval = 5 * time.Minute
+
+// deltaLoadedMsg delivers the issues updated since the last load.
+type deltaLoadedMsg struct {
+	issues []models.Issue
+	err    error
+	took   time.Duration
+}
+
+// resyncTickMsg checks whether a full resync is due.
+type resyncTickMsg struct{}
+
+// deltaState is what incremental refresh knows about the loaded data.
+type deltaState struct {
+	supported bool      // bd list accepts --updated-after
+	watermark time.Time // newest UpdatedAt seen
+	lastFull  time.Time // when the last full load arrived
+
+	index   map[string]int             // issue ID → position in App.issues
+	blocks  map[string]map[string]bool // issue ID → issues it blocks
+	ready   map[string]bool
+	blocked map[string]bool // open issues with an unfinished blocker
+}
+
+// reset rebuilds the state from a full load, taking bd's word for which
+// issues are ready.
+func (d *deltaState) reset(issues, readyIssues []models.Issue) {
+	d.lastFull = time.Now()
+	d.watermark = time.Time{}
+	d.index = make(map[string]int, len(issues))
+	d.blocks = make(map[string]map[string]bool)
+	d.ready = make(map[string]bool, len(readyIssues))
+	d.blocked = make(map[string]bool)
+	for i := range issues {
+		d.index[issues[i].ID] = i
+		d.link(&issues[i])
+		if issues[i].UpdatedAt.After(d.watermark) {
+			d.watermark = issues[i].UpdatedAt
+		}
+	}
+	for _, ri := range readyIssues {
+		d.ready[ri.ID] = true
+	}
+	for i := range issues {
+		if isBlocked(&issues[i], issues, d.index) {
+			d.blocked[issues[i].ID] = true
+		}
+	}
+}
+
+// link and unlink add and remove an issue's blockers in the blocks index.
+func (d *deltaState) link(issue *models.Issue) {
+	for _, id := range blockerIDs(issue) {
+		if d.blocks[id] == nil {
+			d.blocks[id] = make(map[string]bool)
+		}
+		d.blocks[id][issue.ID] = true
+	}
+}
+
+func (d *deltaState) unlink(issue *models.Issue) {
+	for _, id := range blockerIDs(issue) {
+		delete(d.blocks[id], issue.ID)
+	}
+}
+
+// blockerIDs returns the issues that block issue through a "blocks"
+// dependency.
+func blockerIDs(issue *models.Issue) []string {
+	var ids []string
+	for _, dep := range issue.Dependencies {
+		if dep.DepTypeValue() == "blocks" {
+			ids = append(ids, dep.ParentID())
+		}
+	}
+	return ids
+}
+
+// isBlocked reports whether an unfinished issue waits on another that
+// isn't closed. Blockers missing from the list don't count.
+func isBlocked(issue *models.Issue, issues []models.Issue, index map[string]int) bool {
+	if issue.Status == "closed" {
+		return false
+	}
+	for _, id := range blockerIDs(issue) {
+		if i, ok := index[id]; ok && issues[i].Status != "closed" {
+			return true
+		}
+	}
+	return false
+}
+
+// isReady approximates bd ready: open, not deferred, and not blocked.
+func isReady(issue *models.Issue, blocked bool, now time.Time) bool {
+	if issue.Status != "open" || blocked {
+		return false
+	}
+	return issue.DeferUntil == nil || !issue.DeferUntil.After(now)
+}
+
+// refreshData reloads in the background, fetching only what changed when
+// it can.
+func (a *App) refreshData() tea.Cmd {
+	d := &a.delta
+	if !d.supported || d.lastFull.IsZero() || d.watermark.IsZero() ||
+		time.Since(d.lastFull) >= fullResyncInterval {
+		return a.loadDataQuiet()
+	}
+	// Step back a second: bd may store timestamps at second precision,
+	// and issues already seen are skipped when merging.
+	return a.loadDelta(d.watermark.Add(-time.Second))
+}
+
+func (a *App) loadDelta(since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		issues, err := a.client.ListUpdatedSince(since)
+		return deltaLoadedMsg{issues: issues, err: err, took: time.Since(start)}
+	}
+}
+
+// resyncTick schedules the next check for a due full resync.
+func (a *App) resyncTick() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg { return resyncTickMsg{} })
+}
+
+// handleDelta merges an incremental load, falling back to a full load
+// if it failed.
+func (a *App) handleDelta(msg deltaLoadedMsg) tea.Cmd {
+	a.refreshStats.recordDelta(msg.took, msg.err)
+	a.diag.Error("incremental refresh", msg.err)
+	if msg.err != nil {
+		if bd.IsUnknownFlag(msg.err) {
+			a.delta.supported = false
+		}
+		return a.loadDataQuiet()
+	}
+	a.refreshSucceeded()
+	return a.applyDelta(msg.issues)
+}
+
+// applyDelta merges issues updated since the last load into the data and
+// updates everything derived from it.
+func (a *App) applyDelta(fetched []models.Issue) tea.Cmd {
+	d := &a.delta
+	issues := slices.Clone(a.issues)
+	prev := make(map[string]models.Issue)
+	var changed []models.Issue
+	for _, issue := range fetched {
+		if i, ok := d.index[issue.ID]; ok {
+			// Already seen, or older than what a full load since brought.
+			if !issue.UpdatedAt.After(issues[i].UpdatedAt) {
+				continue
+			}
+			prev[issue.ID] = issues[i]
+			issues[i] = issue
+		} else {
+			d.index[issue.ID] = len(issues)
+			issues = append(issues, issue)
+		}
+		changed = append(changed, issue)
+		if issue.UpdatedAt.After(d.watermark) {
+			d.watermark = issue.UpdatedAt
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// The changed issues and anything they block may have become ready
+	// or blocked.
+	affected := make(map[string]bool)
+	for i := range changed {
+		if old, ok := prev[changed[i].ID]; ok {
+			d.unlink(&old)
+		}
+		d.link(&changed[i])
+		affected[changed[i].ID] = true
+		for id := range d.blocks[changed[i].ID] {
+			affected[id] = true
+		}
+	}
+	blockedDelta := 0
+	now := time.Now()
+	for id := range affected {
+		issue := &issues[d.index[id]]
+		blocked := isBlocked(issue, issues, d.index)
+		switch {
+		case blocked && !d.blocked[id]:
+			blockedDelta++
+		case !blocked && d.blocked[id]:
+			blockedDelta--
+		}
+		setFlag(d.blocked, id, blocked)
+		setFlag(d.ready, id, isReady(issue, blocked, now))
+	}
+
+	var readyIssues []models.Issue
+	for i := range issues {
+		if d.ready[issues[i].ID] {
+			readyIssues = append(readyIssues, issues[i])
+		}
+	}
+	var stats *models.StatsSummary
+	if a.stats != nil {
+		s := *a.stats
+		for i := range changed {
+			if old, ok := prev[changed[i].ID]; ok {
+				countStatus(&s, &old, -1)
+			} else {
+				s.TotalIssues++
+			}
+			countStatus(&s, &changed[i], 1)
+		}
+		s.BlockedIssues += blockedDelta
+		s.ReadyIssues = len(readyIssues)
+		stats = &s
+	}
+
+	a.issues = issues
+	a.readyIssues = readyIssues
+	a.stats = stats
+	a.cache.invalidate(updatedTimes(issues))
+	changes := a.list.MergeData(issues, changed, d.ready, stats)
+	return a.dataChanged(changes)
+}
+
+// setFlag adds id to set when on and removes it otherwise.
+func setFlag(set map[string]bool, id string, on bool) {
+	if on {
+		set[id] = true
+	} else {
+		delete(set, id)
+	}
+}
+
+// countStatus adds n to the stats counters an issue falls under.
+func countStatus(s *models.StatsSummary, issue *models.Issue, n int) {
+	switch issue.Status {
+	case "open":
+		s.OpenIssues += n
+	case "in_progress":
+		s.InProgressIssues += n
+	case "closed":
+		s.ClosedIssues += n
+	case "deferred":
+		s.DeferredIssues += n
+	case "tombstone":
+		s.TombstoneIssues += n
+	}
+	if issue.Pinned {
+		s.PinnedIssues += n
+	}
+}
+
+// updatedTimes maps each issue to its UpdatedAt, for cache invalidation.
+func updatedTimes(issues []models.Issue) map[string]time.Time {
+	updated := make(map[string]time.Time, len(issues))
+	for i := range issues {
+		updated[issues[i].ID] = issues[i].UpdatedAt
+	}
+	return updated
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/views"
+)
+
+// Background refreshes don't interrupt with an error screen, but they
+// don't fail silently either: the list header warns with the number of
+// failures in a row and the age of the data shown, E shows the errors,
+// and a full reload is retried with exponential backoff until one
+// succeeds and clears the warning.
+
+const (
+	retryMin = 2 * time.Second
+	retryMax = time.Minute
+)
+
+// refreshRetryMsg retries a failed background refresh.
+type refreshRetryMsg struct{ seq int }
+
+// refreshFailures tracks background refreshes failing in a row.
+type refreshFailures struct {
+	count   int
+	err     error     // latest failure
+	since   time.Time // first failure in the current run
+	lastOK  time.Time // last successful data load
+	delay   time.Duration
+	retryAt time.Time // zero when no retry is scheduled
+	seq     int       // bumped to cancel a scheduled retry
+}
+
+// refreshSucceeded clears the failure state after a successful load.
+func (a *App) refreshSucceeded() {
+	f := &a.failures
+	if f.count > 0 {
+		a.diag.Event("refresh recovered", "failures", f.count)
+	}
+	f.count = 0
+	f.err = nil
+	f.delay = 0
+	f.retryAt = time.Time{}
+	f.seq++
+	f.lastOK = time.Now()
+	a.list.SetRefreshFailures(0, f.lastOK)
+	if a.showErrors {
+		a.errorView.SetSections(a.errorSections())
+	}
+}
+
+// refreshFailed records a failed background refresh and schedules a retry
+// unless one is already pending.
+func (a *App) refreshFailed(err error) tea.Cmd {
+	f := &a.failures
+	f.count++
+	f.err = err
+	if f.count == 1 {
+		f.since = time.Now()
+	}
+	a.list.SetRefreshFailures(f.count, f.lastOK)
+
+	var cmds []tea.Cmd
+	if f.count == 1 {
+		cmds = append(cmds, a.setStatus("refresh failed: "+firstLine(err.Error())+" (E for details)"))
+	}
+	if f.retryAt.IsZero() {
+		f.delay = min(max(f.delay*2, retryMin), retryMax)
+		f.retryAt = time.Now().Add(f.delay)
+		seq := f.seq
+		cmds = append(cmds, tea.Tick(f.delay, func(time.Time) tea.Msg {
+			return refreshRetryMsg{seq: seq}
+		}))
+	}
+	if a.showErrors {
+		a.errorView.SetSections(a.errorSections())
+	}
+	return tea.Batch(cmds...)
+}
+
+// retryRefresh runs a scheduled retry with a full reload.
+func (a *App) retryRefresh(msg refreshRetryMsg) tea.Cmd {
+	if msg.seq != a.failures.seq || a.failures.count == 0 {
+		return nil
+	}
+	a.failures.retryAt = time.Time{}
+	a.diag.Event("retrying refresh", "failures", a.failures.count)
+	return a.loadDataQuiet()
+}
+
+// openErrors shows the refresh error overlay.
+func (a *App) openErrors() tea.Cmd {
+	if a.failures.count == 0 && len(a.diag.Errors()) == 0 {
+		return a.setStatus("no refresh errors")
+	}
+	a.showErrors = true
+	a.errorView.SetSections(a.errorSections())
+	return nil
+}
+
+// errorSections lists the current failure in full, then recent errors.
+func (a *App) errorSections() []views.DebugSection {
+	var sections []views.DebugSection
+	f := a.failures
+	if f.count > 0 {
+		lastOK := "never"
+		if !f.lastOK.IsZero() {
+			lastOK = fmt.Sprintf("%s (%s ago)", f.lastOK.Format("15:04:05"), roundDuration(time.Since(f.lastOK)))
+		}
+		retry := "on the next change"
+		if !f.retryAt.IsZero() {
+			retry = "in " + roundDuration(max(0, time.Until(f.retryAt)))
+		}
+		status := views.DebugSection{Title: "Refresh failing", Rows: []views.DebugRow{
+			{Label: "failures", Value: fmt.Sprintf("%d in a row since %s", f.count, f.since.Format("15:04:05"))},
+			{Label: "last success", Value: lastOK},
+			{Label: "next retry", Value: retry},
+		}}
+		for i, line := range strings.Split(strings.TrimSpace(f.err.Error()), "\n") {
+			label := ""
+			if i == 0 {
+				label = "error"
+			}
+			status.Rows = append(status.Rows, views.DebugRow{Label: label, Value: line})
+		}
+		sections = append(sections, status)
+	}
+
+	recent := views.DebugSection{Title: "Recent errors"}
+	for i, e := range a.diag.Errors() {
+		if i == debugErrors {
+			break
+		}
+		recent.Rows = append(recent.Rows, views.DebugRow{
+			Label: e.At.Format("15:04:05") + " " + e.Source,
+			Value: firstLine(e.Err.Error()),
+		})
+	}
+	if len(recent.Rows) > 0 {
+		sections = append(sections, recent)
+	}
+	return sections
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/views"
+)
+
+// Browser-style navigation history. Leaving the list for a detail page,
+// drilling into another issue, backing out of a detail, and changing the
+// list's filter or sort each record where you were; H and L (or alt+left
+// and alt+right) step back and forward through those stops.
+
+// maxHistory caps each of the back and forward stacks.
+const maxHistory = 100
+
+// place is a stop in the navigation history: either a list state or a
+// detail page together with the drill-down chain beneath it.
+type place struct {
+	list   *views.ListState
+	detail *detailState
+}
+
+// navHistory holds the places behind and ahead of the current one.
+type navHistory struct {
+	back    []place
+	forward []place
+}
+
+// visit records p as the place being left for a new one, which discards
+// anything ahead.
+func (h *navHistory) visit(p place) {
+	h.back = push(h.back, p)
+	h.forward = nil
+}
+
+func push(stack []place, p place) []place {
+	stack = append(stack, p)
+	if len(stack) > maxHistory {
+		stack = stack[len(stack)-maxHistory:]
+	}
+	return stack
+}
+
+// currentPlace returns the place on screen, if it is one history records.
+func (a *App) currentPlace() (place, bool) {
+	switch a.viewMode {
+	case ViewList:
+		st := a.list.State()
+		return place{list: &st}, true
+	case ViewDetail:
+		if a.detail != nil {
+			return place{detail: &detailState{
+				detail: a.detail,
+				stack:  slices.Clone(a.detailStack),
+				ret:    a.detailReturn,
+			}}, true
+		}
+	}
+	return place{}, false
+}
+
+// recordVisit records the current place before navigating away from it.
+func (a *App) recordVisit() {
+	if p, ok := a.currentPlace(); ok {
+		a.history.visit(p)
+		if p.list != nil {
+			a.listMark = *p.list
+		}
+	}
+}
+
+// recordListChange records the list's previous state when a key changed
+// its filter or sort. Edits to the filter text count once, when the input
+// closes, against the state from before it opened.
+func (a *App) recordListChange(before views.ListState, wasFiltering bool) {
+	if !wasFiltering {
+		a.listMark = before
+	}
+	if a.list.IsFiltering() {
+		return
+	}
+	if now := a.list.State(); !now.SameView(a.listMark) {
+		mark := a.listMark
+		a.history.visit(place{list: &mark})
+		a.listMark = now
+	}
+}
+
+// goBack returns to the previous place in the history.
+func (a *App) goBack() tea.Cmd {
+	if len(a.history.back) == 0 {
+		return a.setStatus("no earlier history")
+	}
+	p := a.history.back[len(a.history.back)-1]
+	a.history.back = a.history.back[:len(a.history.back)-1]
+	if cur, ok := a.currentPlace(); ok {
+		a.history.forward = push(a.history.forward, cur)
+	}
+	return a.restorePlace(p)
+}
+
+// goForward undoes goBack.
+func (a *App) goForward() tea.Cmd {
+	if len(a.history.forward) == 0 {
+		return a.setStatus("no later history")
+	}
+	p := a.history.forward[len(a.history.forward)-1]
+	a.history.forward = a.history.forward[:len(a.history.forward)-1]
+	if cur, ok := a.currentPlace(); ok {
+		a.history.back = push(a.history.back, cur)
+	}
+	return a.restorePlace(p)
+}
+
+// restorePlace shows a place from the history. Detail pages come back as
+// they were left, scroll position included, and are refreshed quietly.
+func (a *App) restorePlace(p place) tea.Cmd {
+	if p.list != nil {
+		a.list.RestoreState(*p.list)
+		a.listMark = *p.list
+		a.detail = nil
+		a.detailStack = nil
+		a.viewMode = ViewList
+		return a.schedulePreview()
+	}
+	s := p.detail
+	a.detail, a.detailStack, a.detailReturn = s.detail, slices.Clone(s.stack), s.ret
+	a.detail.SetRawMarkdown(a.rawMarkdown)
+	a.detail.SetSize(a.width, a.height)
+	a.applyStar(a.detail)
+	a.viewMode = ViewDetail
+	return a.loadDetailQuiet(a.detail.IssueID())
+}
+
+// noteRecent adds an issue to the recently-viewed list. It is saved
+// later by flushRecent, keeping file writes out of navigation.
+func (a *App) noteRecent(issue *models.Issue) {
+	a.recent.Add(issue.ID, issue.Title, time.Now())
+	a.recentDirty = true
+}
+
+// flushRecent saves the recently-viewed list if it changed. A failure is
+// only recorded: history is a convenience, not worth interrupting for.
+func (a *App) flushRecent() {
+	if !a.recentDirty {
+		return
+	}
+	a.recentDirty = false
+	a.diag.Error("saving recent issues", a.recent.Save())
+}
+
+// openHistory shows the history picker.
+func (a *App) openHistory() {
+	if a.viewMode != ViewHistory {
+		a.historyReturn = a.viewMode
+	}
+	a.historyView.SetRecent(a.recent.Issues())
+	a.viewMode = ViewHistory
+}
+
+// closeHistory returns to the view the picker was opened from. The
+// picker leaves the detail chain alone, so nothing needs restoring.
+func (a *App) closeHistory() {
+	a.viewMode = a.historyReturn
+}
+
+func (a *App) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		a.closeHistory()
+		return a, nil
+	}
+	cmd := a.historyView.Update(msg)
+	return a, cmd
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+	"github.com/poiley/beady/internal/views"
+)
+
+// Split-pane layout: the list and a live preview of the issue under the
+// cursor share the screen, side by side on wide terminals and stacked on
+// tall ones.
+const (
+	// previewDelay debounces bd show while the cursor is moving.
+	previewDelay = 150 * time.Millisecond
+
+	// Side by side needs a wide terminal whose width is at least
+	// sideBySideAspect times its height (cells are about twice as tall as
+	// they are wide, so this is a landscape window).
+	sideBySideMinWidth = 100
+	sideBySideAspect   = 2.5
+
+	defaultSplitRatio = 0.5
+	minSplitRatio     = 0.2
+	maxSplitRatio     = 0.8
+	splitRatioStep    = 0.05
+)
+
+// previewTickMsg fires when the cursor has rested on an issue for
+// previewDelay. Stale ticks (seq != App.previewSeq) are ignored.
+type previewTickMsg struct {
+	seq int
+}
+
+// previewLoadedMsg delivers the full issue for the preview pane.
+type previewLoadedMsg struct {
+	issue *models.Issue
+	err   error
+}
+
+// sideBySide reports whether the split panes sit next to each other
+// rather than one above the other.
+func (a *App) sideBySide() bool {
+	return a.width >= sideBySideMinWidth && float64(a.width) >= sideBySideAspect*float64(a.height)
+}
+
+// paneSizes returns the list and preview pane dimensions, leaving one
+// column or row for the divider.
+func (a *App) paneSizes() (listW, listH, prevW, prevH int) {
+	if a.sideBySide() {
+		listW = max(20, int(float64(a.width-1)*a.splitRatio))
+		return listW, a.height, max(1, a.width-1-listW), a.height
+	}
+	listH = max(6, int(float64(a.height-1)*a.splitRatio))
+	return a.width, listH, a.width, max(1, a.height-1-listH)
+}
+
+// layout sizes the list (and preview) for the current mode.
+func (a *App) layout() {
+	if !a.split {
+		a.list.SetSize(a.width, a.height)
+		return
+	}
+	listW, listH, prevW, prevH := a.paneSizes()
+	a.list.SetSize(listW, listH)
+	if a.preview != nil {
+		a.preview.SetSize(prevW, prevH)
+	}
+}
+
+// toggleSplit turns the split-pane layout on or off.
+func (a *App) toggleSplit() tea.Cmd {
+	a.split = !a.split
+	a.preview = nil
+	a.previewID = ""
+	a.layout()
+	if a.split {
+		return a.schedulePreview()
+	}
+	return nil
+}
+
+// resizeSplit grows (delta > 0) or shrinks the list pane.
+func (a *App) resizeSplit(delta float64) {
+	a.splitRatio = max(minSplitRatio, min(maxSplitRatio, a.splitRatio+delta))
+	a.layout()
+}
+
+// schedulePreview follows the list cursor. A cached full issue is shown
+// straight away; otherwise the list's copy is shown while bd show is
+// debounced.
+func (a *App) schedulePreview() tea.Cmd {
+	if !a.split {
+		return nil
+	}
+	issue := a.list.SelectedIssue()
+	if issue == nil {
+		a.preview = nil
+		a.previewID = ""
+		return nil
+	}
+	if issue.ID == a.previewID {
+		return nil
+	}
+	a.previewID = issue.ID
+	a.previewSeq++
+
+	if full, ok := a.cache.get(issue.ID); ok {
+		a.setPreview(full, false)
+		return nil
+	}
+	partial := *issue
+	a.setPreview(&partial, false)
+	seq := a.previewSeq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq}
+	})
+}
+
+// refreshPreview re-fetches the previewed issue after a data load if the
+// list says it changed since it was cached.
+func (a *App) refreshPreview() tea.Cmd {
+	if !a.split {
+		return nil
+	}
+	issue := a.list.SelectedIssue()
+	if issue == nil || issue.ID != a.previewID {
+		return a.schedulePreview()
+	}
+	if _, ok := a.cache.get(issue.ID); ok {
+		return nil
+	}
+	return a.loadPreview(issue.ID)
+}
+
+// setPreview shows issue in the preview pane. keepScroll preserves the
+// scroll position when it's a refresh of the same issue.
+func (a *App) setPreview(issue *models.Issue, keepScroll bool) {
+	if keepScroll && a.preview != nil && a.preview.IssueID() == issue.ID {
+		a.preview.UpdateIssue(issue)
+		return
+	}
+	a.preview = views.NewDetailView(issue)
+	a.preview.SetPreview(true)
+	a.preview.SetRawMarkdown(a.rawMarkdown)
+	a.applyStar(a.preview)
+	_, _, prevW, prevH := a.paneSizes()
+	a.preview.SetSize(prevW, prevH)
+}
+
+func (a *App) loadPreview(id string) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := a.client.Show(id)
+		return previewLoadedMsg{issue: issue, err: err}
+	}
+}
+
+// renderSplit draws the list and preview panes with a divider.
+func (a *App) renderSplit() string {
+	listW, listH, prevW, prevH := a.paneSizes()
+	pane := func(s string, w, h int) string {
+		return lipgloss.NewStyle().Width(w).MaxWidth(w).Height(h).MaxHeight(h).Render(s)
+	}
+
+	right := ""
+	if a.preview != nil {
+		right = a.preview.View()
+	} else {
+		right = lipgloss.NewStyle().Foreground(ui.ColorGray).Render("  no issue selected")
+	}
+
+	dividerStyle := lipgloss.NewStyle().Foreground(ui.ColorBorder)
+	if a.sideBySide() {
+		divider := dividerStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", listH), "\n"))
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			pane(a.list.View(), listW, listH), divider, pane(right, prevW, prevH))
+	}
+	divider := dividerStyle.Render(strings.Repeat("─", a.width))
+	return lipgloss.JoinVertical(lipgloss.Left,
+		pane(a.list.View(), listW, listH), divider, pane(right, prevW, prevH))
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/views"
+)
+
+// starredView is the "starred" saved view: every starred issue, closed
+// ones included, most recently updated first.
+var starredView = views.ListState{
+	SortField:    views.SortByUpdated,
+	StatusFilter: views.FilterStarred,
+}
+
+// toggleStar stars or unstars an issue.
+func (a *App) toggleStar(id string) tea.Cmd {
+	if a.stars.Toggle(id, time.Now()) {
+		return a.saveStars(fmt.Sprintf("starred %s", id))
+	}
+	return a.saveStars(fmt.Sprintf("unstarred %s", id))
+}
+
+// saveStars persists the stars and refreshes every view showing them,
+// reporting status (or the save error) in the status bar.
+func (a *App) saveStars(status string) tea.Cmd {
+	a.list.SetStars(a.stars.IDs())
+	for _, dv := range append([]*views.DetailView{a.detail, a.preview}, a.detailStack...) {
+		a.applyStar(dv)
+	}
+	if err := a.stars.Save(); err != nil {
+		return a.setStatus(fmt.Sprintf("saving stars: %s", err))
+	}
+	return a.setStatus(status)
+}
+
+// applyStar shows the user's star and note on a detail view.
+func (a *App) applyStar(dv *views.DetailView) {
+	if dv != nil {
+		id := dv.IssueID()
+		dv.SetStar(a.stars.Has(id), a.stars.Note(id))
+	}
+}
+
+// openStarred switches the list to the starred saved view.
+func (a *App) openStarred() tea.Cmd {
+	a.recordVisit()
+	return a.restorePlace(place{list: &starredView})
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/selfupdate"
+)
+
+// The update check is opt-in ("update": {"check": true} in the config).
+// At startup bdy asks GitHub for the newest release on the configured
+// channel, at most once per updateCheckInterval: the answer is kept in
+// the cache dir, and until the next check the remembered one is used.
+// A newer release shows "update available" in the list header.
+
+const updateCheckInterval = 24 * time.Hour
+
+// updateCheckedMsg delivers the newest release tag.
+type updateCheckedMsg struct {
+	latest string
+	err    error
+}
+
+// checkForUpdate shows a remembered newer release and checks again if
+// the last check is due. Development builds aren't checked.
+func (a *App) checkForUpdate() tea.Cmd {
+	if !a.cfg.Update.Check || !selfupdate.IsVersion(a.version) {
+		return nil
+	}
+	channel := a.cfg.Update.Channel
+	state := config.LoadUpdateCheck()
+	if state.Channel == channel {
+		a.showUpdate(state.Latest)
+	}
+	if !state.Due(channel, updateCheckInterval) {
+		return nil
+	}
+	return func() tea.Msg {
+		latest, err := selfupdate.Latest(selfupdate.Options{
+			BaseURL: os.Getenv("BDY_UPDATE_URL"),
+			Channel: channel,
+		})
+		// A failed check counts too, so an offline machine isn't retried
+		// on every start; the last answer is kept.
+		if state.Channel != channel {
+			state.Latest = ""
+		}
+		state.Checked = time.Now()
+		state.Channel = channel
+		if err == nil {
+			state.Latest = latest
+		}
+		state.Save()
+		return updateCheckedMsg{latest: latest, err: err}
+	}
+}
+
+// handleUpdateCheck shows the result of a background update check.
+func (a *App) handleUpdateCheck(msg updateCheckedMsg) {
+	if msg.err != nil {
+		a.diag.Error("update check", msg.err)
+		return
+	}
+	a.diag.Event("update check", "current", a.version, "latest", msg.latest)
+	a.showUpdate(msg.latest)
+}
+
+// showUpdate flags latest in the header if it is newer than this build.
+func (a *App) showUpdate(latest string) {
+	if selfupdate.Newer(latest, a.version) {
+		a.list.SetUpdateAvailable(latest)
+	} else {
+		a.list.SetUpdateAvailable("")
+	}
+}
@@ -1,77 +1,211 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/poiley/beady/internal/bd"
+	"github.com/poiley/beady/internal/diag"
+	"github.com/poiley/beady/internal/views"
 )
 
 const (
 	debounceDelay = 500 * time.Millisecond
-	pollInterval  = 3 * time.Second
+
+	// The poll interval adapts to activity: it is a tenth of the time since
+	// the database last changed, kept between pollMin and pollMax. Polling
+	// is quick after a burst of writes and backs off while idle.
+	pollMin = 1 * time.Second
+	pollMax = 30 * time.Second
 )
 
 // fileChangedMsg signals that the beads database has been modified on disk.
 type fileChangedMsg struct{}
 
-// dbWatcher watches the .beads/ directory for SQLite database changes
-// and emits fileChangedMsg via a channel that Bubble Tea can consume.
+// dbWatcher watches the beads database directory for changes to the
+// SQLite database (and its WAL) or the JSONL export, and emits
+// fileChangedMsg via a channel that Bubble Tea can consume.
 //
 // Uses a hybrid approach: fsnotify for near-instant detection of local
-// mutations, plus a polling fallback (stat-based) every 3 seconds to
-// catch edge cases where fsnotify misses events (WAL checkpoint file
-// recreation, remote daemon syncs, etc.).
+// mutations, plus an adaptive stat-based poll to catch edge cases where
+// fsnotify misses events (WAL checkpoint file recreation, remote daemon
+// syncs, etc.). If fsnotify is unavailable the poll loop carries on alone;
+// if no database can be found, the watcher is disabled. Either way its
+// health says so, for the header.
+//
+// Polling pauses while the terminal is unfocused (see setPaused). File
+// events still come through, so watch notifications keep arriving.
 type dbWatcher struct {
-	watcher  *fsnotify.Watcher
-	events   chan struct{} // debounced change signal
-	done     chan struct{} // signals shutdown
-	beadsDir string        // path to .beads/ directory
+	watcher  *fsnotify.Watcher // nil when polling only
+	events   chan struct{}     // debounced change signal; nil when disabled
+	done     chan struct{}     // signals shutdown
+	wake     chan struct{}     // interrupts the poll wait when paused changes
+	once     sync.Once
+	beadsDir string // directory holding the database files
+
+	health views.WatchHealth
+	reason string // how the database was found, or why watching is degraded
+	diag   *diag.Recorder
+
+	mu         sync.Mutex
+	paused     bool
+	lastChange time.Time // last change seen by either loop
+	lastMod    time.Time // database mtime when that change was signalled
+	stats      watchStats
 }
 
-// newDBWatcher creates a watcher on the beads SQLite database files.
-// Returns nil if the .beads directory doesn't exist or watching fails —
-// the app falls back to manual refresh only.
-func newDBWatcher(workDir string) *dbWatcher {
-	beadsDir := filepath.Join(workDir, ".beads")
+// watchStats counts the watcher's work, for the debug overlay.
+type watchStats struct {
+	fileEvents int           // fsnotify events on database files
+	polls      int           // stat polls run
+	pollHits   int           // polls that found a change fsnotify missed
+	changes    int           // change signals sent to the app
+	interval   time.Duration // current poll interval
+	paused     bool
+}
 
-	// Check that .beads/ exists before attempting to watch
-	if _, err := os.Stat(beadsDir); err != nil {
-		return nil
+// newDBWatcher locates the beads database (see locateBeads) and watches
+// it. It never returns nil: failures are reported through health.
+func newDBWatcher(workDir, dbPath string, client *bd.Client) *dbWatcher {
+	dw := &dbWatcher{
+		done:       make(chan struct{}),
+		wake:       make(chan struct{}, 1),
+		lastChange: time.Now(),
+		diag:       client.Diag,
 	}
 
+	dir, source, err := locateBeads(workDir, dbPath, client)
+	if err != nil {
+		dw.health = views.WatchDisabled
+		dw.reason = err.Error()
+		return dw
+	}
+	dw.beadsDir = dir
+	dw.events = make(chan struct{}, 1)
+	dw.health = views.WatchLive
+	dw.reason = fmt.Sprintf("watching %s (%s)", dir, source)
+
 	w, err := fsnotify.NewWatcher()
+	if err == nil {
+		// Watch the directory so we catch newly created WAL and export
+		// files (e.g., after a checkpoint removes and recreates the WAL),
+		// and the database files themselves, since some platforms only
+		// report writes to files that are watched directly.
+		if err = w.Add(dir); err == nil {
+			for _, path := range dw.dbFiles() {
+				_ = w.Add(path)
+			}
+		} else {
+			w.Close()
+		}
+	}
 	if err != nil {
-		return nil
+		dw.health = views.WatchPolling
+		dw.reason = fmt.Sprintf("polling %s: %s", dir, err)
+	} else {
+		dw.watcher = w
+		go dw.fsnotifyLoop()
 	}
+	go dw.pollLoop()
 
-	dw := &dbWatcher{
-		watcher:  w,
-		events:   make(chan struct{}, 1),
-		done:     make(chan struct{}),
-		beadsDir: beadsDir,
-	}
-
-	// Watch individual DB files if they exist. The WAL file is the primary
-	// write target in WAL mode — it changes on every transaction commit.
-	// Also watch the main DB file for checkpoints.
-	for _, name := range []string{"beads.db", "beads.db-wal"} {
-		path := filepath.Join(beadsDir, name)
-		if _, err := os.Stat(path); err == nil {
-			_ = w.Add(path)
+	return dw
+}
+
+// locateBeads finds the directory holding the beads database, trying in
+// turn: an explicit --db path, the BEADS_DB and BEADS_DIR environment
+// variables, what bd itself reports, a .beads directory in workDir or any
+// parent, and the .beads directory of the main checkout when workDir is a
+// git worktree. It also returns how the directory was found.
+func locateBeads(workDir, dbPath string, client *bd.Client) (string, string, error) {
+	abs := func(p string) string {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(workDir, p)
+		}
+		return filepath.Clean(p)
+	}
+	// A database path names a file (or a directory holding one).
+	dbDir := func(p string) string {
+		p = abs(p)
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			return p
 		}
+		return filepath.Dir(p)
 	}
 
-	// Also watch the directory itself so we catch newly created WAL files
-	// (e.g., after a checkpoint removes and recreates the WAL).
-	_ = w.Add(beadsDir)
+	if dbPath != "" {
+		return dbDir(dbPath), "--db", nil
+	}
+	if p := os.Getenv("BEADS_DB"); p != "" {
+		return dbDir(p), "BEADS_DB", nil
+	}
+	if p := os.Getenv("BEADS_DIR"); p != "" {
+		return abs(p), "BEADS_DIR", nil
+	}
+	if p, err := client.DatabasePath(); err == nil {
+		return dbDir(p), "bd info", nil
+	}
 
-	go dw.fsnotifyLoop()
-	go dw.pollLoop()
+	for dir := abs("."); ; dir = filepath.Dir(dir) {
+		if isDir(filepath.Join(dir, ".beads")) {
+			return filepath.Join(dir, ".beads"), "directory search", nil
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
 
-	return dw
+	// In a git worktree .beads usually stays with the main checkout,
+	// which is the parent of the common git directory.
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = workDir
+	if out, err := cmd.Output(); err == nil {
+		main := filepath.Dir(abs(strings.TrimSpace(string(out))))
+		if isDir(filepath.Join(main, ".beads")) {
+			return filepath.Join(main, ".beads"), "git worktree", nil
+		}
+	}
+
+	return "", "", errors.New("no beads database found (set BEADS_DB or pass --db)")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// isDBFile reports whether a file name is part of the beads database: a
+// SQLite database, its write-ahead log, or a JSONL export.
+func isDBFile(name string) bool {
+	for _, ext := range []string{".db", ".db-wal", ".jsonl"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// dbFiles lists the database files currently in the beads directory.
+func (dw *dbWatcher) dbFiles() []string {
+	entries, err := os.ReadDir(dw.beadsDir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && isDBFile(e.Name()) {
+			paths = append(paths, filepath.Join(dw.beadsDir, e.Name()))
+		}
+	}
+	return paths
 }
 
 // fsnotifyLoop runs the debounced fsnotify event processing goroutine.
@@ -85,13 +219,13 @@ func (dw *dbWatcher) fsnotifyLoop() {
 			if !ok {
 				return
 			}
-			// Only care about writes and creates (new WAL file after checkpoint)
+			// Only care about writes and creates (new WAL file after
+			// checkpoint, JSONL export rewritten via rename)
 			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
 				continue
 			}
 			// Filter to only DB-related files
-			base := filepath.Base(event.Name)
-			if base != "beads.db" && base != "beads.db-wal" {
+			if !isDBFile(filepath.Base(event.Name)) {
 				continue
 			}
 
@@ -100,6 +234,10 @@ func (dw *dbWatcher) fsnotifyLoop() {
 			if event.Op&fsnotify.Create != 0 {
 				_ = dw.watcher.Add(event.Name)
 			}
+			dw.mu.Lock()
+			dw.stats.fileEvents++
+			dw.mu.Unlock()
+			dw.diag.Event("file event", "file", event.Name, "op", event.Op.String())
 
 			// Start/reset the debounce timer
 			if timer == nil {
@@ -119,14 +257,15 @@ func (dw *dbWatcher) fsnotifyLoop() {
 			// Debounce window expired — emit a single change event
 			timer = nil
 			timerC = nil
-			dw.notify()
+			dw.notify(dw.dbModTime())
 
-		case _, ok := <-dw.watcher.Errors:
+		case err, ok := <-dw.watcher.Errors:
 			if !ok {
 				return
 			}
-			// Ignore watch errors silently — worst case the poll loop
-			// picks up the change within pollInterval.
+			// Nothing to do beyond recording it — worst case the poll
+			// loop picks up the change within pollMax.
+			dw.diag.Error("watcher", err)
 
 		case <-dw.done:
 			if timer != nil {
@@ -141,30 +280,94 @@ func (dw *dbWatcher) fsnotifyLoop() {
 // modification times have changed. This catches any changes that fsnotify
 // misses (WAL recreation, remote daemon writes, platform quirks, etc.).
 func (dw *dbWatcher) pollLoop() {
-	lastMod := dw.dbModTime()
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	dw.markSeen(dw.dbModTime())
+	timer := time.NewTimer(dw.pollInterval())
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			dw.mu.Lock()
+			paused := dw.paused
+			if !paused {
+				dw.stats.polls++
+			}
+			dw.mu.Unlock()
+			if paused {
+				// Sleep until setPaused wakes us.
+				continue
+			}
+			// Only signal what nobody has yet: an fsnotify event may
+			// already have reported this mtime.
 			mod := dw.dbModTime()
-			if mod != lastMod {
-				lastMod = mod
-				dw.notify()
+			dw.mu.Lock()
+			missed := !mod.Equal(dw.lastMod)
+			if missed {
+				dw.stats.pollHits++
 			}
+			dw.mu.Unlock()
+			if missed {
+				dw.diag.Event("poll found change", "mtime", mod)
+				dw.notify(mod)
+			}
+			timer.Reset(dw.pollInterval())
+		case <-dw.wake:
+			// Paused or resumed. On resume the app reloads anyway, so
+			// take the current state as seen rather than signal it again.
+			dw.markSeen(dw.dbModTime())
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(dw.pollInterval())
 		case <-dw.done:
 			return
 		}
 	}
 }
 
-// dbModTime returns the latest modification time across the DB and WAL files.
-// Returns zero time if neither file can be stat'd.
+// pollInterval returns how long to wait before the next poll, based on
+// how recently the database changed.
+func (dw *dbWatcher) pollInterval() time.Duration {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	d := min(max(time.Since(dw.lastChange)/10, pollMin), pollMax)
+	dw.stats.interval = d
+	return d
+}
+
+// setPaused stops or restarts polling, for when the terminal loses and
+// regains focus.
+func (dw *dbWatcher) setPaused(paused bool) {
+	dw.mu.Lock()
+	changed := dw.paused != paused
+	dw.paused = paused
+	dw.stats.paused = paused
+	dw.mu.Unlock()
+	if changed {
+		dw.diag.Event("watcher paused", "paused", paused)
+		select {
+		case dw.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// snapshot returns the watcher's counters.
+func (dw *dbWatcher) snapshot() watchStats {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	return dw.stats
+}
+
+// dbModTime returns the latest modification time across the database
+// files. Returns zero time if none can be stat'd.
 func (dw *dbWatcher) dbModTime() time.Time {
 	var latest time.Time
-	for _, name := range []string{"beads.db", "beads.db-wal"} {
-		if info, err := os.Stat(filepath.Join(dw.beadsDir, name)); err == nil {
+	for _, path := range dw.dbFiles() {
+		if info, err := os.Stat(path); err == nil {
 			if t := info.ModTime(); t.After(latest) {
 				latest = t
 			}
@@ -173,8 +376,22 @@ func (dw *dbWatcher) dbModTime() time.Time {
 	return latest
 }
 
-// notify sends a change event to the events channel (non-blocking).
-func (dw *dbWatcher) notify() {
+// markSeen records mod as the database mtime already accounted for, so
+// the poll loop doesn't signal it.
+func (dw *dbWatcher) markSeen(mod time.Time) {
+	dw.mu.Lock()
+	dw.lastMod = mod
+	dw.mu.Unlock()
+}
+
+// notify sends a change event to the events channel (non-blocking),
+// recording mod, the database mtime it reports.
+func (dw *dbWatcher) notify(mod time.Time) {
+	dw.mu.Lock()
+	dw.lastMod = mod
+	dw.lastChange = time.Now()
+	dw.stats.changes++
+	dw.mu.Unlock()
 	select {
 	case dw.events <- struct{}{}:
 	default:
@@ -186,7 +403,7 @@ func (dw *dbWatcher) notify() {
 // detected, then sends a fileChangedMsg. This is designed to be called
 // repeatedly — each invocation waits for the next change.
 func (dw *dbWatcher) waitForChange() tea.Cmd {
-	if dw == nil {
+	if dw.events == nil {
 		return nil
 	}
 	return func() tea.Msg {
@@ -199,11 +416,12 @@ func (dw *dbWatcher) waitForChange() tea.Cmd {
 	}
 }
 
-// close shuts down the watcher and its goroutine.
+// close shuts down the watcher and its goroutines.
 func (dw *dbWatcher) close() {
-	if dw == nil {
-		return
-	}
-	close(dw.done)
-	dw.watcher.Close()
+	dw.once.Do(func() {
+		close(dw.done)
+		if dw.watcher != nil {
+			dw.watcher.Close()
+		}
+	})
 }
@@ -2,10 +2,15 @@ package bd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os/exec"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/poiley/beady/internal/diag"
 	"github.com/poiley/beady/internal/models"
 )
 
@@ -13,6 +18,12 @@ import (
 type Client struct {
 	// WorkDir is the directory to run bd commands in (for --dir flag or cwd).
 	WorkDir string
+
+	// DB, if set, is passed to bd as --db to select the database.
+	DB string
+
+	// Diag, if set, records every invocation.
+	Diag *diag.Recorder
 }
 
 // NewClient creates a new bd CLI client.
@@ -23,11 +34,16 @@ func NewClient(workDir string) *Client {
 // run executes a bd command and returns stdout.
 func (c *Client) run(args ...string) ([]byte, error) {
 	args = append(args, "--json")
+	if c.DB != "" {
+		args = append(args, "--db", c.DB)
+	}
 	cmd := exec.Command("bd", args...)
 	if c.WorkDir != "" {
 		cmd.Dir = c.WorkDir
 	}
+	start := time.Now()
 	out, err := cmd.Output()
+	c.record(args, start, err)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("bd %s failed: %s\n%s", strings.Join(args, " "), err, string(exitErr.Stderr))
@@ -37,6 +53,23 @@ func (c *Client) run(args ...string) ([]byte, error) {
 	return out, nil
 }
 
+// record reports an invocation to Diag.
+func (c *Client) record(args []string, start time.Time, err error) {
+	if c.Diag == nil {
+		return
+	}
+	rec := diag.Command{Args: args, Start: start, Duration: time.Since(start), Err: err}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		rec.ExitCode = exitErr.ExitCode()
+		rec.Stderr = strings.TrimSpace(string(exitErr.Stderr))
+	case err != nil:
+		rec.ExitCode = -1
+	}
+	c.Diag.Command(rec)
+}
+
 // ListAll returns all issues including closed.
 func (c *Client) ListAll() ([]models.Issue, error) {
 	out, err := c.run("list", "--all", "--limit", "0")
@@ -53,6 +86,30 @@ func (c *Client) ListAll() ([]models.Issue, error) {
 	return issues, nil
 }
 
+// ListUpdatedSince returns the issues, including closed ones, updated
+// after t. Older bd versions without --updated-after fail with an
+// "unknown flag" error (see IsUnknownFlag).
+func (c *Client) ListUpdatedSince(t time.Time) ([]models.Issue, error) {
+	out, err := c.run("list", "--all", "--limit", "0", "--updated-after", t.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 || strings.TrimSpace(string(out)) == "" {
+		return nil, nil
+	}
+	var issues []models.Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list --updated-after output: %w", err)
+	}
+	return issues, nil
+}
+
+// IsUnknownFlag reports whether err is bd rejecting a flag it doesn't
+// know, meaning the installed version lacks a feature.
+func IsUnknownFlag(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "unknown flag")
+}
+
 // Ready returns ready (unblocked) issues.
 func (c *Client) Ready() ([]models.Issue, error) {
 	out, err := c.run("ready", "--limit", "0")
@@ -86,6 +143,20 @@ func (c *Client) Show(id string) (*models.Issue, error) {
 	return &issues[0], nil
 }
 
+// ShowMany returns full details for several issues with a single bd show.
+// Issues that no longer exist are omitted.
+func (c *Client) ShowMany(ids []string) ([]models.Issue, error) {
+	out, err := c.run(append([]string{"show"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	var issues []models.Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd show output: %w", err)
+	}
+	return issues, nil
+}
+
 // Stats returns aggregate statistics.
 func (c *Client) Stats() (*models.StatsSummary, error) {
 	out, err := c.run("stats")
@@ -99,6 +170,44 @@ func (c *Client) Stats() (*models.StatsSummary, error) {
 	return &stats.Summary, nil
 }
 
+// Claim marks an issue in_progress and assigns it to assignee.
+func (c *Client) Claim(id, assignee string) error {
+	_, err := c.run("update", id, "--status", "in_progress", "--assignee", assignee)
+	return err
+}
+
+// UpdateAcceptance replaces an issue's acceptance criteria.
+func (c *Client) UpdateAcceptance(id, criteria string) error {
+	_, err := c.run("update", id, "--acceptance", criteria)
+	return err
+}
+
+// DatabasePath asks bd which database it uses, following any BEADS_DB,
+// BEADS_DIR or --db redirect. It reads the database_path key, which is
+// what `bd info --json` emits (beads cmd/bd/info.go); if a bd version
+// reports something else, the keys it did report are recorded in Diag,
+// where the caller's fallback to searching for .beads then shows.
+func (c *Client) DatabasePath() (string, error) {
+	out, err := c.run("info")
+	if err != nil {
+		return "", err
+	}
+	var info map[string]any
+	if err := json.Unmarshal(out, &info); err != nil {
+		return "", fmt.Errorf("parsing bd info output: %w", err)
+	}
+	if p, ok := info["database_path"].(string); ok && p != "" {
+		return p, nil
+	}
+	keys := []string{"none"}
+	if len(info) > 0 {
+		keys = slices.Sorted(maps.Keys(info))
+	}
+	err = fmt.Errorf("bd info reported no database_path (keys: %s)", strings.Join(keys, ", "))
+	c.Diag.Error("locating database", err)
+	return "", err
+}
+
 // CheckInit verifies that bd is available and the current dir has beads initialized.
 func (c *Client) CheckInit() error {
 	_, err := exec.LookPath("bd")
@@ -106,11 +215,18 @@ func (c *Client) CheckInit() error {
 		return fmt.Errorf("bd CLI not found in PATH. Install with: brew install beads")
 	}
 
-	cmd := exec.Command("bd", "stats", "--json")
+	args := []string{"stats", "--json"}
+	if c.DB != "" {
+		args = append(args, "--db", c.DB)
+	}
+	cmd := exec.Command("bd", args...)
 	if c.WorkDir != "" {
 		cmd.Dir = c.WorkDir
 	}
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err = cmd.Run()
+	c.record(args, start, err)
+	if err != nil {
 		return fmt.Errorf("beads not initialized in this directory. Run: bd init")
 	}
 	return nil
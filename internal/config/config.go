@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/poiley/beady/internal/triage"
+)
+
+// Config is the user's persistent bdy configuration, stored as JSON in
+// $XDG_CONFIG_HOME/bdy/config.json (or the platform equivalent). The
+// BDY_CONFIG environment variable overrides the path.
+type Config struct {
+	// User is the name bdy treats as "me" (claiming, assignee matching).
+	// Empty means detect it the way bd does; see Me.
+	User string `json:"user,omitempty"`
+
+	// Next configures the next-task picker.
+	Next NextConfig `json:"next"`
+
+	// Watches are issues or queries the user wants notifications for.
+	Watches []Watch `json:"watches,omitempty"`
+
+	// Notify controls how notifications are delivered.
+	Notify NotifyConfig `json:"notify"`
+
+	// Update configures self-updates and the background update check.
+	Update UpdateConfig `json:"update"`
+
+	path    string // file this config was loaded from
+	loadErr error  // why the file couldn't be read, if it couldn't
+}
+
+// Watch is a single notification subscription. Exactly one of IssueID or
+// Query is set.
+type Watch struct {
+	// IssueID watches a single issue for any change.
+	IssueID string `json:"issue_id,omitempty"`
+
+	// Query watches for issues that newly match a filter expression such as
+	// "priority:0" or "ready label:backend" (see notify.ParseQuery).
+	Query string `json:"query,omitempty"`
+}
+
+// String returns a short label for the watch.
+func (w Watch) String() string {
+	if w.IssueID != "" {
+		return w.IssueID
+	}
+	return w.Query
+}
+
+// NotifyConfig selects notification channels.
+type NotifyConfig struct {
+	// Bell rings the terminal bell.
+	Bell bool `json:"bell"`
+
+	// OSC selects a desktop notification escape sequence: "9" (iTerm2,
+	// Windows Terminal, kitty), "777" (urxvt, foot, Ghostty), or "" for none.
+	OSC string `json:"osc,omitempty"`
+
+	// Exec is an optional shell command run for every notification. The
+	// title, body and issue ID are passed in BDY_NOTIFY_TITLE,
+	// BDY_NOTIFY_BODY and BDY_NOTIFY_ISSUE.
+	Exec string `json:"exec,omitempty"`
+}
+
+// UpdateConfig configures `bdy update` and the update check.
+type UpdateConfig struct {
+	// Check looks for a new release in the background, at most once a
+	// day, and shows "update available" in the header. Off by default.
+	Check bool `json:"check"`
+
+	// Channel is the release channel: "stable" (the default) or
+	// "prerelease".
+	Channel string `json:"channel,omitempty"`
+}
+
+// NextConfig configures the next-task picker.
+type NextConfig struct {
+	// Weights scale the ranking factors; omitted fields keep their defaults.
+	Weights triage.Weights `json:"weights"`
+}
+
+// Default returns the configuration used when no config file exists.
+func Default() *Config {
+	return &Config{
+		Next:   NextConfig{Weights: triage.DefaultWeights()},
+		Notify: NotifyConfig{Bell: true, OSC: "9"},
+	}
+}
+
+// Me returns the current user's name: the configured user, else BD_ACTOR,
+// else git's user.name, else $USER — the same fallbacks bd uses for actors.
+func (c *Config) Me() string {
+	if c.User != "" {
+		return c.User
+	}
+	if v := os.Getenv("BD_ACTOR"); v != "" {
+		return v
+	}
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return os.Getenv("USER")
+}
+
+// Path returns the config file location.
+func Path() (string, error) {
+	if p := os.Getenv("BDY_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bdy", "config.json"), nil
+}
+
+// Load reads the config file, returning defaults if it does not exist.
+// If the file exists but can't be read or parsed, Load returns defaults
+// along with the error, and Save refuses to overwrite the file.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Default(), err
+	}
+	cfg := Default()
+	cfg.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		cfg.loadErr = err
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		// A partial decode may have set some fields; start clean.
+		cfg = Default()
+		cfg.path = path
+		cfg.loadErr = fmt.Errorf("parsing %s: %w", path, err)
+		return cfg, cfg.loadErr
+	}
+	return cfg, nil
+}
+
+// Save writes the config back to the file it was loaded from. It refuses
+// if that file failed to load, since writing these defaults over it would
+// lose whatever settings it holds.
+func (c *Config) Save() error {
+	if c.loadErr != nil {
+		return fmt.Errorf("not overwriting a config that failed to load (%w); fix or remove it first", c.loadErr)
+	}
+	path := c.path
+	if path == "" {
+		p, err := Path()
+		if err != nil {
+			return err
+		}
+		path = p
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// IsWatched reports whether an issue has an issue watch.
+func (c *Config) IsWatched(id string) bool {
+	for _, w := range c.Watches {
+		if w.IssueID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleIssueWatch adds or removes an issue watch and reports whether the
+// issue is watched afterwards.
+func (c *Config) ToggleIssueWatch(id string) bool {
+	for i, w := range c.Watches {
+		if w.IssueID == id {
+			c.Watches = append(c.Watches[:i], c.Watches[i+1:]...)
+			return false
+		}
+	}
+	c.Watches = append(c.Watches, Watch{IssueID: id})
+	return true
+}
+
+// AddQueryWatch adds a query watch unless an identical one exists.
+func (c *Config) AddQueryWatch(query string) {
+	for _, w := range c.Watches {
+		if w.Query == query {
+			return
+		}
+	}
+	c.Watches = append(c.Watches, Watch{Query: query})
+}
+
+// RemoveWatch removes the watch at index i.
+func (c *Config) RemoveWatch(i int) {
+	if i < 0 || i >= len(c.Watches) {
+		return
+	}
+	c.Watches = append(c.Watches[:i], c.Watches[i+1:]...)
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it
+// into place so a crash never leaves a truncated file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxRecent caps how many recently viewed issues are kept per project.
+const maxRecent = 50
+
+// RecentIssue is an issue the user opened, most recent first in Recent.
+type RecentIssue struct {
+	ID     string    `json:"id"`
+	Title  string    `json:"title"`
+	Viewed time.Time `json:"viewed"`
+}
+
+// Recent is the recently viewed issues of one project, persisted in
+// $XDG_CACHE_HOME/bdy/recent.json (or the platform equivalent) alongside
+// those of other projects. BDY_CACHE_DIR overrides the directory.
+type Recent struct {
+	project string
+	path    string
+	issues  []RecentIssue
+}
+
+// CacheDir returns the directory for bdy's cached state.
+func CacheDir() (string, error) {
+	if d := os.Getenv("BDY_CACHE_DIR"); d != "" {
+		return d, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bdy"), nil
+}
+
+// LoadRecent reads the recent issues of the project in workDir. A missing
+// or unreadable file yields an empty list that can still be saved.
+func LoadRecent(workDir string) *Recent {
+	r := &Recent{project: projectKey(workDir)}
+	dir, err := CacheDir()
+	if err != nil {
+		return r
+	}
+	r.path = filepath.Join(dir, "recent.json")
+	if projects, err := readProjects[[]RecentIssue](r.path); err == nil {
+		r.issues = projects[r.project]
+	}
+	return r
+}
+
+// Issues returns the recent issues, most recently viewed first.
+func (r *Recent) Issues() []RecentIssue {
+	return r.issues
+}
+
+// Add records a view of an issue, moving it to the front.
+func (r *Recent) Add(id, title string, at time.Time) {
+	out := []RecentIssue{{ID: id, Title: title, Viewed: at}}
+	for _, it := range r.issues {
+		if it.ID != id && len(out) < maxRecent {
+			out = append(out, it)
+		}
+	}
+	r.issues = out
+}
+
+// Save writes the list back to the cache directory.
+func (r *Recent) Save() error {
+	return saveProject(r.path, r.project, r.issues, true)
+}
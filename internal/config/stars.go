@@ -0,0 +1,89 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// Star is a personal bookmark on an issue. Unlike the pinned flag, which
+// lives in the beads database and is shared, stars are local to the user.
+type Star struct {
+	Note  string    `json:"note,omitempty"` // private note, shown in the detail header
+	Added time.Time `json:"added"`
+}
+
+// Stars is the starred issues of one project, persisted in stars.json next
+// to the config file alongside those of other projects.
+type Stars struct {
+	project string
+	path    string
+	stars   map[string]Star // issue ID -> star
+}
+
+// LoadStars reads the stars of the project in workDir. A missing file
+// yields an empty set. If the file can't be read or parsed, LoadStars
+// returns an empty set along with the error; saving it then leaves an
+// unreadable file alone and moves a corrupt one aside to stars.json.bad,
+// since the notes in it can't be recreated.
+func LoadStars(workDir string) (*Stars, error) {
+	s := &Stars{project: projectKey(workDir), stars: make(map[string]Star)}
+	cfgPath, err := Path()
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(filepath.Dir(cfgPath), "stars.json")
+	projects, err := readProjects[map[string]Star](s.path)
+	if err != nil {
+		return s, err
+	}
+	if projects[s.project] != nil {
+		s.stars = projects[s.project]
+	}
+	return s, nil
+}
+
+// Has reports whether an issue is starred.
+func (s *Stars) Has(id string) bool {
+	_, ok := s.stars[id]
+	return ok
+}
+
+// Note returns the private note on a starred issue, or "".
+func (s *Stars) Note(id string) string {
+	return s.stars[id].Note
+}
+
+// IDs returns the set of starred issue IDs.
+func (s *Stars) IDs() map[string]bool {
+	ids := make(map[string]bool, len(s.stars))
+	for id := range s.stars {
+		ids[id] = true
+	}
+	return ids
+}
+
+// Toggle stars or unstars an issue and reports whether it is starred
+// afterwards. Unstarring discards the note.
+func (s *Stars) Toggle(id string, at time.Time) bool {
+	if s.Has(id) {
+		delete(s.stars, id)
+		return false
+	}
+	s.stars[id] = Star{Added: at}
+	return true
+}
+
+// SetNote sets the private note on an issue, starring it if needed.
+func (s *Stars) SetNote(id, note string, at time.Time) {
+	star, ok := s.stars[id]
+	if !ok {
+		star.Added = at
+	}
+	star.Note = note
+	s.stars[id] = star
+}
+
+// Save writes the stars back next to the config file.
+func (s *Stars) Save() error {
+	return saveProject(s.path, s.project, s.stars, false)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Local state such as recent issues and stars is kept per project: one
+// JSON file maps each project's absolute directory to its entries, so a
+// single file serves every repository bdy is used in.
+
+// projectKey returns the key a project's state is stored under.
+func projectKey(workDir string) string {
+	if abs, err := filepath.Abs(workDir); err == nil {
+		return abs
+	}
+	return workDir
+}
+
+// projectFile is the on-disk format of a per-project state file.
+type projectFile[T any] struct {
+	Projects map[string]T `json:"projects"`
+}
+
+// corruptError reports a state file that exists but doesn't parse.
+type corruptError struct {
+	path string
+	err  error
+}
+
+func (e *corruptError) Error() string { return fmt.Sprintf("parsing %s: %v", e.path, e.err) }
+func (e *corruptError) Unwrap() error { return e.err }
+
+// readProjects reads a per-project state file. A missing file is empty; one
+// that doesn't parse yields a *corruptError.
+func readProjects[T any](path string) (map[string]T, error) {
+	f := projectFile[T]{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]T), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, &corruptError{path: path, err: err}
+	}
+	if f.Projects == nil {
+		f.Projects = make(map[string]T)
+	}
+	return f.Projects, nil
+}
+
+// saveProject stores one project's entries, re-reading the file first so
+// other projects' entries (possibly saved by another bdy) are kept. For
+// throwaway state (rebuild set) a file that can't be read is rebuilt
+// rather than blocking the save. Otherwise a corrupt file is moved aside
+// to <name>.bad before it is replaced, and an unreadable one isn't
+// touched.
+func saveProject[T any](path, project string, entries T, rebuild bool) error {
+	if path == "" {
+		return errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	projects, err := readProjects[T](path)
+	if err != nil {
+		var corrupt *corruptError
+		switch {
+		case rebuild:
+		case errors.As(err, &corrupt):
+			if err := os.Rename(path, path+".bad"); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		projects = make(map[string]T)
+	}
+	projects[project] = entries
+	data, err := json.MarshalIndent(projectFile[T]{Projects: projects}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// UpdateCheck is the result of the last background update check, kept in
+// update-check.json in the cache directory so bdy checks at most once per
+// interval however often it starts.
+type UpdateCheck struct {
+	Checked time.Time `json:"checked"`
+	Channel string    `json:"channel,omitempty"`
+	Latest  string    `json:"latest,omitempty"` // newest release tag seen
+
+	path string
+}
+
+// LoadUpdateCheck reads the last update check. A missing or unreadable
+// file yields a zero check that can still be saved.
+func LoadUpdateCheck() *UpdateCheck {
+	u := &UpdateCheck{}
+	dir, err := CacheDir()
+	if err != nil {
+		return u
+	}
+	u.path = filepath.Join(dir, "update-check.json")
+	if data, err := os.ReadFile(u.path); err == nil {
+		json.Unmarshal(data, u)
+	}
+	return u
+}
+
+// Due reports whether the last check is older than interval or was for
+// another channel.
+func (u *UpdateCheck) Due(channel string, interval time.Duration) bool {
+	return u.Channel != channel || time.Since(u.Checked) >= interval
+}
+
+// Save writes the check back to the cache directory.
+func (u *UpdateCheck) Save() error {
+	if u.path == "" {
+		return errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(u.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(u.path, append(data, '\n'))
+}
@@ -0,0 +1,164 @@
+// Package diag records what bdy is doing behind the scenes: every bd
+// invocation, watcher activity, errors and slow updates. Records go to an
+// optional structured log file and the most recent are kept in memory for
+// the in-TUI diagnostics overlay.
+package diag
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Limits on what is kept in memory.
+const (
+	maxCommands = 50
+	maxErrors   = 20
+)
+
+// Command is one finished bd invocation.
+type Command struct {
+	Args     []string
+	Start    time.Time
+	Duration time.Duration
+	ExitCode int    // -1 if bd could not be run
+	Stderr   string // trimmed by the caller
+	Err      error
+}
+
+// Error is a failure worth showing even though nothing reported it to
+// the user at the time, such as a failed background refresh.
+type Error struct {
+	Source string
+	Err    error
+	At     time.Time
+}
+
+// Recorder collects diagnostics. It is safe for concurrent use, and a nil
+// *Recorder discards everything, so callers never need to check.
+type Recorder struct {
+	mu       sync.Mutex
+	log      *slog.Logger // nil without a log file
+	file     io.Closer
+	path     string
+	commands []Command // oldest first
+	errors   []Error   // oldest first
+}
+
+// New creates a recorder that only keeps recent records in memory.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Open creates a recorder that also appends JSON log lines to path.
+func Open(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	h := slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return &Recorder{log: slog.New(h), file: f, path: path}, nil
+}
+
+// Close flushes and closes the log file, if any.
+func (r *Recorder) Close() error {
+	if r == nil || r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// Path returns the log file's path, or "" if there is none.
+func (r *Recorder) Path() string {
+	if r == nil {
+		return ""
+	}
+	return r.path
+}
+
+// Command records a bd invocation.
+func (r *Recorder) Command(c Command) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.commands = appendCapped(r.commands, c, maxCommands)
+	r.mu.Unlock()
+	if r.log == nil {
+		return
+	}
+	attrs := []any{
+		"args", c.Args,
+		"duration_ms", c.Duration.Milliseconds(),
+		"exit_code", c.ExitCode,
+	}
+	if c.Stderr != "" {
+		attrs = append(attrs, "stderr", c.Stderr)
+	}
+	if c.Err != nil {
+		attrs = append(attrs, "error", c.Err.Error())
+		r.log.Warn("bd", attrs...)
+		return
+	}
+	r.log.Debug("bd", attrs...)
+}
+
+// Error records a failure from source, such as "refresh" or "watcher".
+func (r *Recorder) Error(source string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mu.Lock()
+	r.errors = appendCapped(r.errors, Error{Source: source, Err: err, At: time.Now()}, maxErrors)
+	r.mu.Unlock()
+	if r.log != nil {
+		r.log.Error(source, "error", err.Error())
+	}
+}
+
+// Event logs something worth knowing when reading the log afterwards,
+// such as a watcher event or a slow update. Events aren't kept in memory.
+func (r *Recorder) Event(msg string, attrs ...any) {
+	if r == nil || r.log == nil {
+		return
+	}
+	r.log.Debug(msg, attrs...)
+}
+
+// Commands returns the most recent bd invocations, newest first.
+func (r *Recorder) Commands() []Command {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newestFirst(r.commands)
+}
+
+// Errors returns the most recent errors, newest first.
+func (r *Recorder) Errors() []Error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newestFirst(r.errors)
+}
+
+func appendCapped[T any](s []T, v T, limit int) []T {
+	s = append(s, v)
+	if len(s) > limit {
+		s = s[len(s)-limit:]
+	}
+	return s
+}
+
+func newestFirst[T any](s []T) []T {
+	out := make([]T, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
@@ -0,0 +1,285 @@
+package graph
+
+import (
+	"sort"
+	"time"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// defaultEstimateMinutes is the weight of an unestimated issue when no issue
+// in the epic has an estimate to average from.
+const defaultEstimateMinutes = 60
+
+// maxBlockers caps how many top blockers an analysis reports.
+const maxBlockers = 10
+
+// PathStep is one issue on the critical path.
+type PathStep struct {
+	Issue     models.Issue
+	Minutes   int  // weight used for this step
+	Estimated bool // false if Minutes is a stand-in for a missing estimate
+	Container bool // has unfinished children; closes when they do
+}
+
+// Blocker is an unfinished issue ranked by how much of the epic waits on it.
+type Blocker struct {
+	Issue  models.Issue
+	Blocks int // unfinished issues transitively blocked by it
+}
+
+// EpicAnalysis is the remaining-work analysis of an epic.
+type EpicAnalysis struct {
+	Epic models.Issue
+
+	Total     int            // descendants (excluding the epic itself)
+	Closed    int            // closed descendants
+	Remaining []models.Issue // unfinished issues in scope, including outside blockers
+
+	// Path is the longest chain of unfinished work, first step first.
+	Path        []PathStep
+	PathMinutes int
+
+	Blockers []Blocker
+}
+
+// OnPath reports whether an issue is on the critical path.
+func (a *EpicAnalysis) OnPath(id string) bool {
+	for _, s := range a.Path {
+		if s.Issue.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkSteps returns the number of path steps that are real work rather
+// than containers waiting on their children.
+func (a *EpicAnalysis) WorkSteps() int {
+	n := 0
+	for _, s := range a.Path {
+		if !s.Container {
+			n++
+		}
+	}
+	return n
+}
+
+// Projected estimates when the critical path will be done if each work step
+// takes the average lead time. ok is false if there's no lead time data.
+func (a *EpicAnalysis) Projected(avgLeadTimeHours float64, now time.Time) (t time.Time, ok bool) {
+	if avgLeadTimeHours <= 0 || len(a.Path) == 0 {
+		return time.Time{}, false
+	}
+	d := time.Duration(float64(a.WorkSteps()) * avgLeadTimeHours * float64(time.Hour))
+	return now.Add(d), true
+}
+
+// AnalyzeEpic walks the epic's parent-child tree and the blocks
+// dependencies of its unfinished descendants to find the critical path and
+// the top blockers. issues is the full list from bd list, whose dependency
+// records carry depends_on_id and type. Returns nil if the epic is unknown.
+func AnalyzeEpic(epicID string, issues []models.Issue) *EpicAnalysis {
+	byID := make(map[string]*models.Issue, len(issues))
+	children := make(map[string][]string)  // parent -> children
+	blockedBy := make(map[string][]string) // issue -> issues blocking it
+	for i := range issues {
+		issue := &issues[i]
+		byID[issue.ID] = issue
+		for _, dep := range issue.Dependencies {
+			switch dep.DepTypeValue() {
+			case "parent-child":
+				children[dep.ParentID()] = append(children[dep.ParentID()], issue.ID)
+			case "blocks":
+				blockedBy[issue.ID] = append(blockedBy[issue.ID], dep.ParentID())
+			}
+		}
+	}
+	epic, ok := byID[epicID]
+	if !ok {
+		return nil
+	}
+	a := &EpicAnalysis{Epic: *epic}
+
+	// Collect descendants breadth-first.
+	inScope := make(map[string]bool)
+	queue := []string{epicID}
+	seen := map[string]bool{epicID: true}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, c := range children[id] {
+			if seen[c] || byID[c] == nil {
+				continue
+			}
+			seen[c] = true
+			a.Total++
+			if byID[c].Status == "closed" {
+				a.Closed++
+			} else {
+				inScope[c] = true
+			}
+			queue = append(queue, c)
+		}
+	}
+
+	// Pull in unfinished outside blockers, transitively.
+	queue = queue[:0]
+	for id := range inScope {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, b := range blockedBy[id] {
+			if inScope[b] || b == epicID || byID[b] == nil || byID[b].Status == "closed" {
+				continue
+			}
+			inScope[b] = true
+			queue = append(queue, b)
+		}
+	}
+
+	ids := make([]string, 0, len(inScope))
+	for id := range inScope {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		a.Remaining = append(a.Remaining, *byID[id])
+	}
+
+	// Predecessors: work that must finish before an issue can. Blockers come
+	// first, and a container can't finish before its unfinished children.
+	preds := make(map[string][]string, len(ids))
+	succs := make(map[string][]string, len(ids))
+	addEdge := func(before, after string) {
+		preds[after] = append(preds[after], before)
+		succs[before] = append(succs[before], after)
+	}
+	for _, id := range ids {
+		for _, b := range blockedBy[id] {
+			if inScope[b] {
+				addEdge(b, id)
+			}
+		}
+		for _, c := range children[id] {
+			if inScope[c] {
+				addEdge(c, id)
+			}
+		}
+	}
+
+	// Weight each issue by its estimate. Containers carry no work of their
+	// own and unestimated leaves get the mean estimate.
+	known, sum := 0, 0
+	for _, id := range ids {
+		if m := byID[id].EstimatedMinutes; m > 0 {
+			known++
+			sum += m
+		}
+	}
+	fallback := defaultEstimateMinutes
+	if known > 0 {
+		fallback = sum / known
+	}
+	weight := func(id string) (int, bool) {
+		if hasInScopeChild(children[id], inScope) {
+			return 0, true
+		}
+		if m := byID[id].EstimatedMinutes; m > 0 {
+			return m, true
+		}
+		return fallback, false
+	}
+
+	// Longest weighted path over the DAG via memoized DFS. Cycles (which bd
+	// normally rejects) are broken by ignoring edges back onto the stack.
+	dist := make(map[string]int, len(ids))
+	best := make(map[string]string, len(ids)) // best predecessor
+	state := make(map[string]int, len(ids))   // 0 new, 1 visiting, 2 done
+	var visit func(id string) int
+	visit = func(id string) int {
+		switch state[id] {
+		case 1:
+			return 0
+		case 2:
+			return dist[id]
+		}
+		state[id] = 1
+		w, _ := weight(id)
+		longest := 0
+		for _, p := range preds[id] {
+			if state[p] == 1 {
+				continue
+			}
+			if d := visit(p); d > longest || best[id] == "" {
+				longest = d
+				best[id] = p
+			}
+		}
+		dist[id] = w + longest
+		state[id] = 2
+		return dist[id]
+	}
+	end := ""
+	for _, id := range ids {
+		if d := visit(id); end == "" || d > dist[end] {
+			end = id
+		}
+	}
+	for id := end; id != ""; id = best[id] {
+		w, estimated := weight(id)
+		a.Path = append([]PathStep{{
+			Issue:     *byID[id],
+			Minutes:   w,
+			Estimated: estimated,
+			Container: hasInScopeChild(children[id], inScope),
+		}}, a.Path...)
+		a.PathMinutes += w
+	}
+
+	// Rank blockers by how many unfinished issues each transitively blocks.
+	for _, id := range ids {
+		reach := make(map[string]bool)
+		stack := append([]string(nil), succs[id]...)
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if reach[n] || n == id {
+				continue
+			}
+			reach[n] = true
+			stack = append(stack, succs[n]...)
+		}
+		// Containers waiting on their own children aren't "blocked" work.
+		count := 0
+		for n := range reach {
+			if len(children[n]) == 0 || !hasInScopeChild(children[n], inScope) {
+				count++
+			}
+		}
+		if count > 0 {
+			a.Blockers = append(a.Blockers, Blocker{Issue: *byID[id], Blocks: count})
+		}
+	}
+	sort.SliceStable(a.Blockers, func(i, j int) bool {
+		if a.Blockers[i].Blocks != a.Blockers[j].Blocks {
+			return a.Blockers[i].Blocks > a.Blockers[j].Blocks
+		}
+		return a.Blockers[i].Issue.Priority < a.Blockers[j].Issue.Priority
+	})
+	if len(a.Blockers) > maxBlockers {
+		a.Blockers = a.Blockers[:maxBlockers]
+	}
+	return a
+}
+
+func hasInScopeChild(children []string, inScope map[string]bool) bool {
+	for _, c := range children {
+		if inScope[c] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package graph
+
+import "github.com/poiley/beady/internal/models"
+
+// Descendants returns every issue under rootID in the parent-child tree,
+// open or closed, breadth-first. The root itself is not included.
+func Descendants(rootID string, issues []models.Issue) []models.Issue {
+	byID := make(map[string]*models.Issue, len(issues))
+	children := make(map[string][]string)
+	for i := range issues {
+		issue := &issues[i]
+		byID[issue.ID] = issue
+		for _, dep := range issue.Dependencies {
+			if dep.DepTypeValue() == "parent-child" {
+				children[dep.ParentID()] = append(children[dep.ParentID()], issue.ID)
+			}
+		}
+	}
+
+	var out []models.Issue
+	seen := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, c := range children[id] {
+			if seen[c] || byID[c] == nil {
+				continue
+			}
+			seen[c] = true
+			out = append(out, *byID[c])
+			queue = append(queue, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+// Package ical renders issue due dates and deferrals as an RFC 5545
+// iCalendar feed.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// ContentType is the MIME type of the feed.
+const ContentType = "text/calendar; charset=utf-8"
+
+// uidDomain qualifies UIDs so they can't collide with other producers.
+const uidDomain = "bdy.beads"
+
+// Write emits a calendar with a VTODO for every issue with a due date and
+// an all-day VEVENT on the day every deferred issue wakes up. UIDs are
+// derived from the issue ID, so calendar apps update entries in place when
+// the feed is refreshed.
+func Write(w io.Writer, name string, issues []models.Issue, now time.Time) error {
+	cw := &writer{w: bufio.NewWriter(w)}
+	cw.prop("BEGIN", "VCALENDAR")
+	cw.prop("VERSION", "2.0")
+	cw.prop("PRODID", "-//poiley//bdy//EN")
+	cw.prop("CALSCALE", "GREGORIAN")
+	if name != "" {
+		cw.prop("X-WR-CALNAME", escape(name))
+	}
+
+	stamp := utc(now)
+	for _, issue := range issues {
+		if issue.DueAt != nil {
+			cw.todo(issue, stamp)
+		}
+		if issue.DeferUntil != nil && issue.Status != "closed" {
+			cw.deferral(issue, stamp)
+		}
+	}
+
+	cw.prop("END", "VCALENDAR")
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (c *writer) todo(issue models.Issue, stamp string) {
+	c.prop("BEGIN", "VTODO")
+	c.prop("UID", issue.ID+"-due@"+uidDomain)
+	c.prop("DTSTAMP", stamp)
+	c.prop("CREATED", utc(issue.CreatedAt))
+	if !issue.UpdatedAt.IsZero() {
+		c.prop("LAST-MODIFIED", utc(issue.UpdatedAt))
+	}
+	c.prop("DUE", utc(*issue.DueAt))
+	c.common(issue, fmt.Sprintf("[%s] %s", issue.ID, issue.Title))
+	c.prop("STATUS", todoStatus(issue.Status))
+	if issue.ClosedAt != nil {
+		c.prop("COMPLETED", utc(*issue.ClosedAt))
+		c.prop("PERCENT-COMPLETE", "100")
+	}
+	c.prop("END", "VTODO")
+}
+
+func (c *writer) deferral(issue models.Issue, stamp string) {
+	day := issue.DeferUntil.Local()
+	c.prop("BEGIN", "VEVENT")
+	c.prop("UID", issue.ID+"-defer@"+uidDomain)
+	c.prop("DTSTAMP", stamp)
+	c.prop("DTSTART;VALUE=DATE", day.Format("20060102"))
+	c.prop("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format("20060102"))
+	c.prop("TRANSP", "TRANSPARENT")
+	c.common(issue, fmt.Sprintf("[%s] wakes: %s", issue.ID, issue.Title))
+	c.prop("STATUS", "CONFIRMED")
+	c.prop("END", "VEVENT")
+}
+
+// common writes the properties shared by todos and events.
+func (c *writer) common(issue models.Issue, summary string) {
+	c.prop("SUMMARY", escape(summary))
+
+	desc := []string{
+		fmt.Sprintf("Status: %s", issue.Status),
+		fmt.Sprintf("Priority: %s", issue.PriorityString()),
+	}
+	if issue.IssueType != "" {
+		desc = append(desc, "Type: "+issue.IssueType)
+	}
+	if issue.Assignee != "" {
+		desc = append(desc, "Assignee: "+issue.Assignee)
+	}
+	if issue.Description != "" {
+		desc = append(desc, "", issue.Description)
+	}
+	c.prop("DESCRIPTION", escape(strings.Join(desc, "\n")))
+	c.prop("PRIORITY", fmt.Sprintf("%d", priority(issue.Priority)))
+	if len(issue.Labels) > 0 {
+		labels := make([]string, len(issue.Labels))
+		for i, l := range issue.Labels {
+			labels[i] = escape(l)
+		}
+		c.prop("CATEGORIES", strings.Join(labels, ","))
+	}
+}
+
+// prop writes a content line, folded at 75 octets as RFC 5545 requires.
+func (c *writer) prop(name, value string) {
+	if c.err != nil {
+		return
+	}
+	line := name + ":" + value
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := utf8.RuneLen(r)
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	b.WriteString("\r\n")
+	_, c.err = c.w.WriteString(b.String())
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// todoStatus maps a bd status to a VTODO STATUS.
+func todoStatus(status string) string {
+	switch status {
+	case "closed":
+		return "COMPLETED"
+	case "in_progress":
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// priority maps P0-P4 onto iCalendar's 1 (highest) to 9 (lowest).
+func priority(p int) int {
+	return min(max(1+2*p, 1), 9)
+}
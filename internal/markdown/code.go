@@ -0,0 +1,179 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/poiley/beady/internal/ui"
+)
+
+var (
+	codeStyle    = lipgloss.NewStyle().Foreground(ui.ColorWhite)
+	keywordStyle = lipgloss.NewStyle().Foreground(ui.ColorMagenta)
+	stringStyle  = lipgloss.NewStyle().Foreground(ui.ColorGreen)
+	numberStyle  = lipgloss.NewStyle().Foreground(ui.ColorYellow)
+	commentStyle = lipgloss.NewStyle().Italic(true).Foreground(ui.ColorGray)
+	langStyle    = lipgloss.NewStyle().Foreground(ui.ColorGray)
+)
+
+// language describes just enough of a language to color it: keywords,
+// line comment markers and string quotes.
+type language struct {
+	keywords map[string]bool
+	comments []string
+	quotes   string
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var (
+	slashComments = []string{"//"}
+
+	languages = map[string]language{
+		"go": {keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var nil true false
+			string int int64 uint8 byte rune bool error float64 any`), comments: slashComments, quotes: "\"'`"},
+		"js": {keywords: words(`async await break case catch class const continue default delete do else export
+			extends false finally for from function if import in instanceof let new null return switch this
+			throw true try typeof undefined var void while yield interface type enum implements`), comments: slashComments, quotes: "\"'`"},
+		"python": {keywords: words(`and as assert async await break class continue def del elif else except False
+			finally for from global if import in is lambda None nonlocal not or pass raise return True try
+			while with yield self`), comments: []string{"#"}, quotes: "\"'"},
+		"rust": {keywords: words(`as async await break const continue crate else enum extern false fn for if impl in
+			let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use
+			where while Some None Ok Err`), comments: slashComments, quotes: "\""},
+		"sh": {keywords: words(`if then else elif fi case esac for while until do done in function return export
+			local readonly set unset echo exit`), comments: []string{"#"}, quotes: "\"'"},
+		"sql": {keywords: words(`select from where and or not insert into values update set delete create table
+			alter drop index join left right inner outer on group by order having limit as null is in like
+			SELECT FROM WHERE AND OR NOT INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE ALTER DROP INDEX
+			JOIN LEFT RIGHT INNER OUTER ON GROUP BY ORDER HAVING LIMIT AS NULL IS IN LIKE`), comments: []string{"--"}, quotes: "'\""},
+		"json": {keywords: words(`true false null`), quotes: "\""},
+		"yaml": {keywords: words(`true false null yes no`), comments: []string{"#"}, quotes: "\"'"},
+	}
+
+	languageAliases = map[string]string{
+		"golang": "go", "javascript": "js", "ts": "js", "typescript": "js", "jsx": "js", "tsx": "js",
+		"py": "python", "rs": "rust", "bash": "sh", "shell": "sh", "zsh": "sh", "console": "sh",
+		"yml": "yaml", "jsonc": "json",
+	}
+)
+
+func lookupLanguage(name string) (language, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	lang, ok := languages[name]
+	return lang, ok
+}
+
+// codeBlock renders a fenced code block with a left rule, hard-wrapping
+// long lines. Known languages get keyword, string, number and comment
+// colors; anything else is shown plain.
+func codeBlock(code []string, langName string, width int) []string {
+	lang, known := lookupLanguage(langName)
+	bar := ruleStyle.Render("│ ")
+	avail := max(width-2, 1)
+
+	var out []string
+	if langName != "" {
+		out = append(out, ruleStyle.Render("┌ ")+langStyle.Render(langName))
+	}
+	for _, line := range code {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		for {
+			chunk := line
+			if runewidth.StringWidth(line) > avail {
+				chunk = runewidth.Truncate(line, avail, "")
+			}
+			if known {
+				out = append(out, bar+highlight(chunk, lang))
+			} else {
+				out = append(out, bar+codeStyle.Render(chunk))
+			}
+			line = line[len(chunk):]
+			if line == "" || chunk == "" {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// highlight colors one line of code.
+func highlight(line string, lang language) string {
+	var b strings.Builder
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			b.WriteString(codeStyle.Render(plain.String()))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); {
+		rest := line[i:]
+		c := line[i]
+
+		comment := false
+		for _, marker := range lang.comments {
+			if strings.HasPrefix(rest, marker) {
+				comment = true
+			}
+		}
+		if comment {
+			flush()
+			b.WriteString(commentStyle.Render(rest))
+			break
+		}
+
+		if strings.IndexByte(lang.quotes, c) >= 0 {
+			end := i + 1
+			for end < len(line) && line[end] != c {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(line))
+			flush()
+			b.WriteString(stringStyle.Render(line[i:end]))
+			i = end
+			continue
+		}
+
+		if isWordByte(c) {
+			end := i
+			for end < len(line) && (isWordByte(line[end]) || line[end] == '.' && c >= '0' && c <= '9') {
+				end++
+			}
+			word := line[i:end]
+			switch {
+			case c >= '0' && c <= '9':
+				flush()
+				b.WriteString(numberStyle.Render(word))
+			case lang.keywords[word]:
+				flush()
+				b.WriteString(keywordStyle.Render(word))
+			default:
+				plain.WriteString(word)
+			}
+			i = end
+			continue
+		}
+
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,230 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/poiley/beady/internal/ui"
+)
+
+var (
+	codeSpanStyle = lipgloss.NewStyle().Foreground(ui.ColorCyan)
+	linkStyle     = lipgloss.NewStyle().Foreground(ui.ColorBlue).Underline(true)
+)
+
+// segment is a run of text with one style, optionally a hyperlink.
+type segment struct {
+	text  string
+	style lipgloss.Style
+	link  string
+}
+
+// render styles the segment and wraps it in an OSC 8 hyperlink if it has
+// one. Each segment is self-contained so lines can be cut between them.
+func (s segment) render() string {
+	out := s.style.Render(s.text)
+	if s.link != "" {
+		out = ansi.SetHyperlink(s.link) + out + ansi.ResetHyperlink()
+	}
+	return out
+}
+
+// parseInline splits a line of markdown into styled segments: code spans,
+// strong, emphasis, strikethrough, links and autolinks.
+func parseInline(s string, base lipgloss.Style) []segment {
+	var segs []segment
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			segs = append(segs, segment{text: plain.String(), style: base})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_~[]()<>#|!", rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := strings.Index(rest[ticks:], rest[:ticks]); end >= 0 {
+				flush()
+				code := strings.TrimSpace(rest[ticks : ticks+end])
+				segs = append(segs, segment{text: code, style: codeSpanStyle})
+				i += 2*ticks + end
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 {
+				flush()
+				segs = append(segs, parseInline(rest[2:2+end], base.Bold(true))...)
+				i += 4 + end
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if end := strings.Index(rest[2:], "~~"); end > 0 {
+				flush()
+				segs = append(segs, parseInline(rest[2:2+end], base.Strikethrough(true))...)
+				i += 4 + end
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			// Emphasis must hug its text; underscores must also sit on word
+			// boundaries so snake_case identifiers are left alone.
+			c := rest[:1]
+			if len(rest) > 1 && rest[1] != ' ' && (c == "*" || i == 0 || !isWordByte(s[i-1])) {
+				if end := strings.Index(rest[1:], c); end > 0 && rest[end] != ' ' &&
+					(c == "*" || 1+end+1 >= len(rest) || !isWordByte(rest[1+end+1])) {
+					flush()
+					segs = append(segs, parseInline(rest[1:1+end], base.Italic(true))...)
+					i += 2 + end
+					continue
+				}
+			}
+
+		case rest[0] == '[':
+			if mid := strings.Index(rest, "]("); mid > 0 {
+				if end := strings.IndexByte(rest[mid+2:], ')'); end >= 0 {
+					url := strings.TrimSpace(rest[mid+2 : mid+2+end])
+					flush()
+					for _, seg := range parseInline(rest[1:mid], linkStyle) {
+						seg.link = url
+						segs = append(segs, seg)
+					}
+					i += mid + 3 + end
+					continue
+				}
+			}
+
+		case rest[0] == '<' && (strings.HasPrefix(rest, "<http://") || strings.HasPrefix(rest, "<https://")):
+			if end := strings.IndexByte(rest, '>'); end > 0 {
+				flush()
+				url := rest[1:end]
+				segs = append(segs, segment{text: url, style: linkStyle, link: url})
+				i += end + 1
+				continue
+			}
+
+		case strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://"):
+			if i == 0 || !isWordByte(s[i-1]) {
+				end := strings.IndexAny(rest, " \t")
+				if end < 0 {
+					end = len(rest)
+				}
+				url := strings.TrimRight(rest[:end], ".,;:!?)")
+				flush()
+				segs = append(segs, segment{text: url, style: linkStyle, link: url})
+				i += len(url)
+				continue
+			}
+		}
+		plain.WriteByte(rest[0])
+		i++
+	}
+	flush()
+	return segs
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// plainText returns the unstyled text of segments.
+func plainText(segs []segment) string {
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteString(s.text)
+	}
+	return b.String()
+}
+
+// wrap lays segments out in lines of at most width columns, breaking at
+// spaces. first prefixes the first line and rest the others; both may be
+// styled. Words wider than a line are split.
+func wrap(segs []segment, width int, first, rest string) []string {
+	type word []segment
+	var words []word
+	var cur word
+	for _, seg := range segs {
+		parts := strings.Split(seg.text, " ")
+		for j, p := range parts {
+			if j > 0 && len(cur) > 0 {
+				words = append(words, cur)
+				cur = nil
+			}
+			if p != "" {
+				piece := seg
+				piece.text = p
+				cur = append(cur, piece)
+			}
+		}
+	}
+	if len(cur) > 0 {
+		words = append(words, cur)
+	}
+
+	var lines []string
+	var line strings.Builder
+	prefix := first
+	avail := max(width-lipgloss.Width(prefix), 1)
+	used := 0
+	newLine := func() {
+		lines = append(lines, prefix+line.String())
+		line.Reset()
+		prefix = rest
+		avail = max(width-lipgloss.Width(prefix), 1)
+		used = 0
+	}
+
+	for _, w := range words {
+		ww := runewidth.StringWidth(plainText(w))
+		if used > 0 && used+1+ww > avail {
+			newLine()
+		}
+		if used > 0 {
+			line.WriteByte(' ')
+			used++
+		}
+		for _, piece := range w {
+			// Split pieces that don't fit on an empty line.
+			for runewidth.StringWidth(piece.text) > avail-used {
+				if used >= avail {
+					newLine()
+					continue
+				}
+				head := runewidth.Truncate(piece.text, avail-used, "")
+				if head == "" && used > 0 {
+					newLine()
+					continue
+				}
+				if head == "" {
+					// A single rune wider than the line; emit it anyway.
+					_, size := utf8.DecodeRuneInString(piece.text)
+					head = piece.text[:size]
+				}
+				part := piece
+				part.text = head
+				line.WriteString(part.render())
+				piece.text = piece.text[len(head):]
+				newLine()
+			}
+			line.WriteString(piece.render())
+			used += runewidth.StringWidth(piece.text)
+		}
+	}
+	if used > 0 || len(lines) == 0 {
+		lines = append(lines, prefix+line.String())
+	}
+	return lines
+}
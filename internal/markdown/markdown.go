@@ -0,0 +1,190 @@
+// Package markdown renders the markdown used in issue text fields into
+// width-limited, ANSI-styled terminal lines.
+//
+// It covers what shows up in bd issues — headings, emphasis, lists and task
+// checkboxes, fenced code with keyword highlighting, tables, block quotes
+// and links (as OSC 8 hyperlinks) — not the full CommonMark spec. Single
+// newlines are kept as line breaks, as in GitHub comments, so plain-text
+// descriptions render the way they were written.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/ui"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	hrRe       = regexp.MustCompile(`^\s{0,3}(-(\s*-){2,}|\*(\s*\*){2,}|_(\s*_){2,})\s*$`)
+	fenceRe    = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([\\w+#-]*)")
+	listRe     = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	taskRe     = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	quoteRe    = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	tableSepRe = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+var (
+	h1Style     = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(ui.ColorYellow)
+	h2Style     = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorYellow)
+	h3Style     = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorBlue)
+	hnStyle     = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite)
+	ruleStyle   = lipgloss.NewStyle().Foreground(ui.ColorDimGray)
+	quoteStyle  = lipgloss.NewStyle().Italic(true).Foreground(ui.ColorGray)
+	bulletStyle = lipgloss.NewStyle().Foreground(ui.ColorBlue)
+	checkStyle  = lipgloss.NewStyle().Foreground(ui.ColorGreen)
+	doneStyle   = lipgloss.NewStyle().Foreground(ui.ColorGray).Strikethrough(true)
+)
+
+// Render renders markdown source into lines no wider than width columns.
+func Render(src string, width int) []string {
+	lines, _ := RenderTasks(src, width)
+	return lines
+}
+
+// RenderTasks is Render that also reports where task-list items landed:
+// tasks maps each item's zero-based source line to its first rendered line.
+// Items inside block quotes are rendered but not reported.
+func RenderTasks(src string, width int) (lines []string, tasks map[int]int) {
+	r := &renderer{width: max(width, 10), tasks: make(map[int]int)}
+	r.blocks(strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n"), r.width)
+	// Drop trailing blank lines.
+	for len(r.out) > 0 && r.out[len(r.out)-1] == "" {
+		r.out = r.out[:len(r.out)-1]
+	}
+	return r.out, r.tasks
+}
+
+type renderer struct {
+	width int
+	out   []string
+	tasks map[int]int // source line -> output line; nil in nested renderers
+}
+
+func (r *renderer) emit(lines ...string) {
+	r.out = append(r.out, lines...)
+}
+
+// blank emits a single blank line, collapsing runs and skipping leading
+// blanks.
+func (r *renderer) blank() {
+	if len(r.out) > 0 && r.out[len(r.out)-1] != "" {
+		r.out = append(r.out, "")
+	}
+}
+
+func (r *renderer) blocks(lines []string, width int) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.blank()
+
+		case fenceRe.MatchString(line):
+			m := fenceRe.FindStringSubmatch(line)
+			fence, lang := m[1], m[2]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence[:3]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			r.emit(codeBlock(code, lang, width)...)
+
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			style := hnStyle
+			switch len(m[1]) {
+			case 1:
+				style = h1Style
+			case 2:
+				style = h2Style
+			case 3:
+				style = h3Style
+			}
+			r.emit(wrap(parseInline(m[2], style), width, "", "")...)
+
+		case hrRe.MatchString(line):
+			r.emit(ruleStyle.Render(strings.Repeat("─", width)))
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableSepRe.MatchString(lines[i+1]):
+			rows := []string{line}
+			sep := lines[i+1]
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				rows = append(rows, lines[i])
+			}
+			i--
+			r.emit(table(rows, sep, width)...)
+
+		case quoteRe.MatchString(line):
+			var inner []string
+			for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+				inner = append(inner, quoteRe.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			sub := &renderer{width: width}
+			sub.blocks(inner, max(width-2, 10))
+			bar := ruleStyle.Render("│ ")
+			for _, l := range sub.out {
+				r.emit(bar + quoteStyle.Render(l))
+			}
+
+		case listRe.MatchString(line):
+			i = r.listItem(lines, i, width)
+
+		default:
+			r.emit(wrap(parseInline(line, lipgloss.NewStyle()), width, "", "")...)
+		}
+	}
+}
+
+// listItem renders the list item at lines[i], folding in indented
+// continuation lines, and returns the index of its last line.
+func (r *renderer) listItem(lines []string, i, width int) int {
+	m := listRe.FindStringSubmatch(lines[i])
+	indent, marker, text := len(strings.ReplaceAll(m[1], "\t", "    ")), m[2], m[3]
+	level := indent / 2
+	start := i
+
+	for i+1 < len(lines) {
+		next := lines[i+1]
+		if strings.TrimSpace(next) == "" || listRe.MatchString(next) || !strings.HasPrefix(next, strings.Repeat(" ", indent+2)) {
+			break
+		}
+		text += " " + strings.TrimSpace(next)
+		i++
+	}
+
+	var bullet string
+	style := lipgloss.NewStyle()
+	switch {
+	case len(marker) > 1 || marker[0] >= '0' && marker[0] <= '9':
+		bullet = bulletStyle.Render(marker)
+	default:
+		bullet = bulletStyle.Render([]string{"•", "◦", "▪"}[level%3])
+	}
+	if t := taskRe.FindStringSubmatch(text); t != nil {
+		if r.tasks != nil {
+			r.tasks[start] = len(r.out)
+		}
+		text = t[2]
+		if t[1] == " " {
+			bullet = bulletStyle.Render("☐")
+		} else {
+			bullet = checkStyle.Render("☑")
+			style = doneStyle
+		}
+	}
+
+	lead := strings.Repeat("  ", level)
+	first := lead + bullet + " "
+	rest := lead + strings.Repeat(" ", lipgloss.Width(bullet)+1)
+	r.emit(wrap(parseInline(text, style), width, first, rest)...)
+	return i
+}
@@ -0,0 +1,150 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/poiley/beady/internal/ui"
+)
+
+var tableHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorBlue)
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+	alignRight
+)
+
+// splitRow splits a table row into trimmed cells, honoring \| escapes.
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// table renders a markdown table. Columns are sized to their content and
+// shrunk, widest first, until the table fits; cells that still don't fit
+// are truncated.
+func table(rows []string, sep string, width int) []string {
+	var aligns []align
+	for _, spec := range splitRow(sep) {
+		switch {
+		case strings.HasPrefix(spec, ":") && strings.HasSuffix(spec, ":"):
+			aligns = append(aligns, alignCenter)
+		case strings.HasSuffix(spec, ":"):
+			aligns = append(aligns, alignRight)
+		default:
+			aligns = append(aligns, alignLeft)
+		}
+	}
+	ncols := len(aligns)
+
+	cells := make([][][]segment, len(rows))
+	widths := make([]int, ncols)
+	for r, row := range rows {
+		style := lipgloss.NewStyle()
+		if r == 0 {
+			style = tableHeaderStyle
+		}
+		raw := splitRow(row)
+		cells[r] = make([][]segment, ncols)
+		for c := 0; c < ncols; c++ {
+			if c < len(raw) {
+				cells[r][c] = parseInline(raw[c], style)
+			}
+			widths[c] = max(widths[c], runewidth.StringWidth(plainText(cells[r][c])))
+		}
+	}
+
+	// Shrink the widest column until the table fits (3 columns of padding
+	// and separator between each pair).
+	total := func() int {
+		sum := 3 * (ncols - 1)
+		for _, w := range widths {
+			sum += w
+		}
+		return sum
+	}
+	for total() > width {
+		widest := 0
+		for c := range widths {
+			if widths[c] > widths[widest] {
+				widest = c
+			}
+		}
+		if widths[widest] <= 3 {
+			break
+		}
+		widths[widest]--
+	}
+
+	sepStyle := ruleStyle
+	renderRow := func(row [][]segment) string {
+		parts := make([]string, ncols)
+		for c, segs := range row {
+			parts[c] = cell(segs, widths[c], aligns[c])
+		}
+		return strings.Join(parts, sepStyle.Render(" │ "))
+	}
+
+	out := []string{renderRow(cells[0])}
+	rule := make([]string, ncols)
+	for c, w := range widths {
+		rule[c] = strings.Repeat("─", w)
+	}
+	out = append(out, sepStyle.Render(strings.Join(rule, "─┼─")))
+	for _, row := range cells[1:] {
+		out = append(out, renderRow(row))
+	}
+	return out
+}
+
+// cell renders one cell padded to width. Content that is too wide is
+// truncated as plain text in the first segment's style.
+func cell(segs []segment, width int, a align) string {
+	text := plainText(segs)
+	w := runewidth.StringWidth(text)
+	var body string
+	if w > width {
+		style := lipgloss.NewStyle()
+		if len(segs) > 0 {
+			style = segs[0].style
+		}
+		body = style.Render(ui.Truncate(text, width))
+		w = runewidth.StringWidth(ui.Truncate(text, width))
+	} else {
+		for _, s := range segs {
+			body += s.render()
+		}
+	}
+	gap := max(width-w, 0)
+	switch a {
+	case alignRight:
+		return strings.Repeat(" ", gap) + body
+	case alignCenter:
+		return strings.Repeat(" ", gap/2) + body + strings.Repeat(" ", gap-gap/2)
+	default:
+		return body + strings.Repeat(" ", gap)
+	}
+}
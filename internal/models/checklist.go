@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// checklistRe matches a markdown task-list item: "- [ ] text", "* [x] text",
+// "1. [X] text". Group 1 is everything up to the box, group 2 the mark.
+var checklistRe = regexp.MustCompile(`^(\s*(?:[-*+]|\d{1,9}[.)])\s+\[)([ xX])\]\s+(.*)$`)
+
+// ChecklistItem is one task-list entry in a markdown text field.
+type ChecklistItem struct {
+	Line int    // zero-based line in the source text
+	Text string // item text after the checkbox
+	Done bool
+}
+
+// ParseChecklist returns the task-list items in markdown text, skipping
+// fenced code blocks.
+func ParseChecklist(text string) []ChecklistItem {
+	var items []ChecklistItem
+	fence := ""
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		if m := checklistRe.FindStringSubmatch(line); m != nil {
+			items = append(items, ChecklistItem{Line: i, Text: m[3], Done: m[2] != " "})
+		}
+	}
+	return items
+}
+
+// ToggleChecklistItem flips the checkbox on the given source line and
+// returns the new text. It reports false if that line is not a task item.
+func ToggleChecklistItem(text string, line int) (string, bool) {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return text, false
+	}
+	m := checklistRe.FindStringSubmatchIndex(lines[line])
+	if m == nil {
+		return text, false
+	}
+	mark := "x"
+	if lines[line][m[4]:m[5]] != " " {
+		mark = " "
+	}
+	lines[line] = lines[line][:m[4]] + mark + lines[line][m[5]:]
+	return strings.Join(lines, "\n"), true
+}
+
+// ChecklistProgress counts checked and total items in a checklist.
+type ChecklistProgress struct {
+	Done  int
+	Total int
+}
+
+// Progress summarizes a checklist.
+func Progress(items []ChecklistItem) ChecklistProgress {
+	p := ChecklistProgress{Total: len(items)}
+	for _, it := range items {
+		if it.Done {
+			p.Done++
+		}
+	}
+	return p
+}
+
+// String formats the progress as "done/total", or "" for an empty list.
+func (p ChecklistProgress) String() string {
+	if p.Total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", p.Done, p.Total)
+}
+
+// Complete reports whether every item is checked.
+func (p ChecklistProgress) Complete() bool {
+	return p.Total > 0 && p.Done == p.Total
+}
+
+// AcceptanceProgress returns the checklist progress of the issue's
+// acceptance criteria.
+func (i *Issue) AcceptanceProgress() ChecklistProgress {
+	return Progress(ParseChecklist(i.AcceptanceCriteria))
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChangeKind classifies a detected change to an issue between two data loads.
+type ChangeKind int
+
+const (
+	ChangeCreated ChangeKind = iota
+	ChangeStatus
+	ChangePriority
+	ChangeComment
+	ChangeClosed
+	ChangeUpdated // any other field change
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeCreated:
+		return "created"
+	case ChangeStatus:
+		return "status"
+	case ChangePriority:
+		return "priority"
+	case ChangeComment:
+		return "comment"
+	case ChangeClosed:
+		return "closed"
+	default:
+		return "updated"
+	}
+}
+
+// FieldChange is a single field's before/after value.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// IssueChange describes how one issue differs from its previous snapshot.
+type IssueChange struct {
+	IssueID string
+	Title   string
+	Kind    ChangeKind
+	At      time.Time     // the issue's UpdatedAt (or CreatedAt for new issues)
+	Fields  []FieldChange // empty for ChangeCreated
+	By      string        // who made the change, if known; see changeActor
+}
+
+// Summary returns a one-line description like "status open→in_progress by
+// alice".
+func (c IssueChange) Summary() string {
+	summary := "created"
+	if c.Kind != ChangeCreated {
+		var parts []string
+		for _, f := range c.Fields {
+			parts = append(parts, fmt.Sprintf("%s %s→%s", f.Field, orDash(f.Before), orDash(f.After)))
+		}
+		summary = "updated"
+		if len(parts) > 0 {
+			summary = strings.Join(parts, ", ")
+		}
+	}
+	if c.By != "" {
+		summary += " by " + c.By
+	}
+	return summary
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// DiffIssues compares the previous snapshot of an issue with its current
+// state and returns the fields that changed, in display order. Only fields
+// present in bd list output are compared.
+func DiffIssues(prev, cur *Issue) []FieldChange {
+	var changes []FieldChange
+	cmp := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, FieldChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	cmp("title", prev.Title, cur.Title)
+	cmp("status", prev.Status, cur.Status)
+	cmp("priority", prev.PriorityString(), cur.PriorityString())
+	cmp("type", prev.IssueType, cur.IssueType)
+	cmp("assignee", prev.Assignee, cur.Assignee)
+	cmp("due", formatTimePtr(prev.DueAt), formatTimePtr(cur.DueAt))
+	cmp("defer", formatTimePtr(prev.DeferUntil), formatTimePtr(cur.DeferUntil))
+	cmp("estimate", prev.EstimateString(), cur.EstimateString())
+	cmp("comments", countString(prev.CommentCount), countString(cur.CommentCount))
+	cmp("deps", fmt.Sprintf("%d/%d", prev.DependencyCount, prev.DependentCount),
+		fmt.Sprintf("%d/%d", cur.DependencyCount, cur.DependentCount))
+	cmp("labels", strings.Join(prev.Labels, ","), strings.Join(cur.Labels, ","))
+	cmp("criteria", prev.AcceptanceProgress().String(), cur.AcceptanceProgress().String())
+	if prev.Pinned != cur.Pinned {
+		cmp("pinned", fmt.Sprintf("%t", prev.Pinned), fmt.Sprintf("%t", cur.Pinned))
+	}
+	return changes
+}
+
+// ClassifyChange picks the most significant kind for a set of field changes.
+func ClassifyChange(cur *Issue, fields []FieldChange) ChangeKind {
+	kind := ChangeUpdated
+	for _, f := range fields {
+		switch f.Field {
+		case "status":
+			if cur.Status == "closed" {
+				return ChangeClosed
+			}
+			kind = ChangeStatus
+		case "priority":
+			if kind == ChangeUpdated || kind == ChangeComment {
+				kind = ChangePriority
+			}
+		case "comments":
+			if kind == ChangeUpdated {
+				kind = ChangeComment
+			}
+		}
+	}
+	return kind
+}
+
+// changeActor guesses who made a change. bd records no actor for updates,
+// so this is the issue's assignee for status and assignee changes (the
+// person working it is usually the one moving it), and the author of the
+// newest comment for comment changes, when the issue carries its comments
+// (bd show output does; bd list output doesn't). Otherwise it is "".
+func changeActor(cur *Issue, kind ChangeKind, fields []FieldChange) string {
+	if kind == ChangeComment {
+		var latest *Comment
+		for _, c := range cur.Comments {
+			if c != nil && (latest == nil || c.CreatedAt.After(latest.CreatedAt)) {
+				latest = c
+			}
+		}
+		if latest != nil {
+			return latest.Author
+		}
+		return ""
+	}
+	for _, f := range fields {
+		if f.Field == "status" || f.Field == "assignee" {
+			return cur.Assignee
+		}
+	}
+	return ""
+}
+
+// DetectChanges compares a new issue list against snapshots from the previous
+// load, keyed by issue ID. Issues whose UpdatedAt did not move are skipped.
+func DetectChanges(prev map[string]Issue, issues []Issue) []IssueChange {
+	var changes []IssueChange
+	for i := range issues {
+		cur := &issues[i]
+		old, existed := prev[cur.ID]
+		if !existed {
+			changes = append(changes, IssueChange{
+				IssueID: cur.ID,
+				Title:   cur.Title,
+				Kind:    ChangeCreated,
+				At:      cur.CreatedAt,
+				By:      cur.CreatedBy,
+			})
+			continue
+		}
+		if cur.UpdatedAt.Equal(old.UpdatedAt) {
+			continue
+		}
+		fields := DiffIssues(&old, cur)
+		kind := ClassifyChange(cur, fields)
+		changes = append(changes, IssueChange{
+			IssueID: cur.ID,
+			Title:   cur.Title,
+			Kind:    kind,
+			At:      cur.UpdatedAt,
+			Fields:  fields,
+			By:      changeActor(cur, kind, fields),
+		})
+	}
+	return changes
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func countString(n int) string {
+	return fmt.Sprintf("%d", n)
+}
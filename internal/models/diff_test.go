@@ -0,0 +1,198 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectChanges(t *testing.T) {
+	t0 := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	base := Issue{
+		ID:           "bd-1",
+		Title:        "Fix login",
+		Status:       "open",
+		Priority:     2,
+		Assignee:     "alice",
+		CommentCount: 1,
+		CreatedAt:    t0,
+		CreatedBy:    "bob",
+		UpdatedAt:    t0,
+	}
+	// edit returns base updated at t1 with f applied.
+	edit := func(f func(*Issue)) Issue {
+		issue := base
+		issue.UpdatedAt = t1
+		f(&issue)
+		return issue
+	}
+
+	tests := []struct {
+		name    string
+		prev    []Issue
+		cur     Issue
+		want    []IssueChange
+		summary string
+	}{
+		{
+			name: "status change",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Status = "in_progress" }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeStatus, At: t1,
+				Fields: []FieldChange{{Field: "status", Before: "open", After: "in_progress"}},
+				By:     "alice",
+			}},
+			summary: "status open→in_progress by alice",
+		},
+		{
+			name: "priority change",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Priority = 0 }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangePriority, At: t1,
+				Fields: []FieldChange{{Field: "priority", Before: "P2", After: "P0"}},
+			}},
+			summary: "priority P2→P0",
+		},
+		{
+			name: "new issue",
+			cur:  base,
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeCreated, At: t0, By: "bob",
+			}},
+			summary: "created by bob",
+		},
+		{
+			name: "close",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Status = "closed"; i.Priority = 1 }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeClosed, At: t1,
+				Fields: []FieldChange{
+					{Field: "status", Before: "open", After: "closed"},
+					{Field: "priority", Before: "P2", After: "P1"},
+				},
+				By: "alice",
+			}},
+			summary: "status open→closed, priority P2→P1 by alice",
+		},
+		{
+			name: "comment count bump",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.CommentCount = 2 }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeComment, At: t1,
+				Fields: []FieldChange{{Field: "comments", Before: "1", After: "2"}},
+			}},
+			summary: "comments 1→2",
+		},
+		{
+			name: "comment with authors",
+			prev: []Issue{base},
+			cur: edit(func(i *Issue) {
+				i.CommentCount = 2
+				i.Comments = []*Comment{
+					{Author: "dave", CreatedAt: t1},
+					{Author: "alice", CreatedAt: t0},
+				}
+			}),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeComment, At: t1,
+				Fields: []FieldChange{{Field: "comments", Before: "1", After: "2"}},
+				By:     "dave",
+			}},
+			summary: "comments 1→2 by dave",
+		},
+		{
+			name: "other field",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Title = "Fix logout" }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix logout", Kind: ChangeUpdated, At: t1,
+				Fields: []FieldChange{{Field: "title", Before: "Fix login", After: "Fix logout"}},
+			}},
+			summary: "title Fix login→Fix logout",
+		},
+		{
+			name: "assignee change",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Assignee = "" }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeUpdated, At: t1,
+				Fields: []FieldChange{{Field: "assignee", Before: "alice", After: ""}},
+			}},
+			summary: "assignee alice→-",
+		},
+		{
+			name: "reassigned",
+			prev: []Issue{base},
+			cur:  edit(func(i *Issue) { i.Assignee = "carol" }),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeUpdated, At: t1,
+				Fields: []FieldChange{{Field: "assignee", Before: "alice", After: "carol"}},
+				By:     "carol",
+			}},
+			summary: "assignee alice→carol by carol",
+		},
+		{
+			name: "only UpdatedAt moved",
+			prev: []Issue{base},
+			cur:  edit(func(*Issue) {}),
+			want: []IssueChange{{
+				IssueID: "bd-1", Title: "Fix login", Kind: ChangeUpdated, At: t1,
+			}},
+			summary: "updated",
+		},
+		{
+			name: "unchanged",
+			prev: []Issue{base},
+			cur:  base,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := make(map[string]Issue)
+			for _, issue := range tt.prev {
+				prev[issue.ID] = issue
+			}
+			got := DetectChanges(prev, []Issue{tt.cur})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DetectChanges =\n  %+v\nwant\n  %+v", got, tt.want)
+			}
+			if len(got) > 0 {
+				if s := got[0].Summary(); s != tt.summary {
+					t.Errorf("Summary = %q, want %q", s, tt.summary)
+				}
+			}
+		})
+	}
+}
+
+func TestClassifyChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		fields []string
+		want   ChangeKind
+	}{
+		{name: "no fields", status: "open", want: ChangeUpdated},
+		{name: "status beats priority", status: "in_progress", fields: []string{"priority", "status"}, want: ChangeStatus},
+		{name: "close beats everything", status: "closed", fields: []string{"comments", "priority", "status"}, want: ChangeClosed},
+		{name: "priority beats comments", status: "open", fields: []string{"comments", "priority"}, want: ChangePriority},
+		{name: "comments beat other fields", status: "open", fields: []string{"title", "comments"}, want: ChangeComment},
+		{name: "other fields", status: "open", fields: []string{"title", "labels"}, want: ChangeUpdated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []FieldChange
+			for _, f := range tt.fields {
+				fields = append(fields, FieldChange{Field: f})
+			}
+			if got := ClassifyChange(&Issue{Status: tt.status}, fields); got != tt.want {
+				t.Errorf("ClassifyChange = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
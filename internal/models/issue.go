@@ -139,6 +139,29 @@ func (i *Issue) LeadTime() time.Duration {
 	return i.ClosedAt.Sub(i.CreatedAt)
 }
 
+// IsOverdue reports whether the issue has a due date before now and is not
+// closed.
+func (i *Issue) IsOverdue(now time.Time) bool {
+	return i.DueAt != nil && i.Status != "closed" && now.After(*i.DueAt)
+}
+
+// FormatDuration formats a duration compactly, like "45m", "5h" or "3d 4h".
+func FormatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours < 1 {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	if hours < 24 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	days := hours / 24
+	rh := hours % 24
+	if rh > 0 {
+		return fmt.Sprintf("%dd %dh", days, rh)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
 // RelativeAge returns a human-readable relative time string.
 func RelativeAge(t time.Time) string {
 	d := time.Since(t)
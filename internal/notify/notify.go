@@ -0,0 +1,227 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/models"
+)
+
+// execTimeout bounds how long a notification hook may run.
+const execTimeout = 10 * time.Second
+
+// Notification is a single alert raised by a watch.
+type Notification struct {
+	At      time.Time
+	IssueID string
+	Title   string // short headline, e.g. "bd-42 became ready"
+	Body    string // detail, e.g. the issue title or change summary
+	Watch   string // label of the watch that fired
+}
+
+// Snapshot is the state of the database at one data load, used to decide
+// whether a watch condition newly became true.
+type Snapshot struct {
+	Issues map[string]models.Issue
+	Ready  map[string]bool
+}
+
+// NewSnapshot indexes a data load.
+func NewSnapshot(issues, readyIssues []models.Issue) Snapshot {
+	s := Snapshot{
+		Issues: make(map[string]models.Issue, len(issues)),
+		Ready:  make(map[string]bool, len(readyIssues)),
+	}
+	for _, issue := range issues {
+		s.Issues[issue.ID] = issue
+	}
+	for _, issue := range readyIssues {
+		s.Ready[issue.ID] = true
+	}
+	return s
+}
+
+// Empty reports whether the snapshot has no data (i.e. first load).
+func (s Snapshot) Empty() bool {
+	return len(s.Issues) == 0
+}
+
+// Evaluate compares two snapshots against the configured watches and
+// returns the notifications to raise. changes are the per-issue changes
+// detected between prev and cur.
+//
+// Issue watches fire once for any change to the issue, or when it becomes
+// ready, saying so rather than naming the change.
+// Query watches fire when an issue newly matches the query, either because
+// it was just created or because it changed into a matching state.
+func Evaluate(watches []config.Watch, prev, cur Snapshot, changes []models.IssueChange) []Notification {
+	if prev.Empty() {
+		return nil
+	}
+	now := time.Now()
+	byID := make(map[string]models.IssueChange, len(changes))
+	for _, c := range changes {
+		byID[c.IssueID] = c
+	}
+
+	var out []Notification
+	for _, w := range watches {
+		if w.IssueID != "" {
+			issue, ok := cur.Issues[w.IssueID]
+			if !ok {
+				continue
+			}
+			// Becoming ready is usually caused by a change to the issue;
+			// raise one notification for it, with the change as detail.
+			c, changed := byID[w.IssueID]
+			if cur.Ready[w.IssueID] && !prev.Ready[w.IssueID] {
+				body := issue.Title
+				if changed && len(c.Fields) > 0 {
+					body += " (" + c.Summary() + ")"
+				}
+				out = append(out, Notification{
+					At:      now,
+					IssueID: issue.ID,
+					Title:   fmt.Sprintf("%s became ready", issue.ID),
+					Body:    body,
+					Watch:   w.String(),
+				})
+			} else if changed {
+				out = append(out, Notification{
+					At:      now,
+					IssueID: issue.ID,
+					Title:   fmt.Sprintf("%s %s", issue.ID, c.Kind),
+					Body:    c.Summary(),
+					Watch:   w.String(),
+				})
+			}
+			continue
+		}
+
+		q, err := ParseQuery(w.Query)
+		if err != nil {
+			continue
+		}
+		for id := range byID {
+			issue, ok := cur.Issues[id]
+			if !ok || !q.Match(&issue, cur.Ready[id]) {
+				continue
+			}
+			old, existed := prev.Issues[id]
+			if existed && q.Match(&old, prev.Ready[id]) {
+				continue
+			}
+			verb := "now matches"
+			if !existed {
+				verb = "filed, matches"
+			}
+			out = append(out, Notification{
+				At:      now,
+				IssueID: id,
+				Title:   fmt.Sprintf("%s %s %q", id, verb, q),
+				Body:    issue.Title,
+				Watch:   w.String(),
+			})
+		}
+		// Readiness changes don't bump UpdatedAt, so check ready-only queries
+		// against issues that just entered the ready set.
+		for id := range cur.Ready {
+			if prev.Ready[id] {
+				continue
+			}
+			if _, changed := byID[id]; changed {
+				continue
+			}
+			issue, ok := cur.Issues[id]
+			if !ok || !q.Match(&issue, true) {
+				continue
+			}
+			if old, existed := prev.Issues[id]; existed && q.Match(&old, prev.Ready[id]) {
+				continue
+			}
+			out = append(out, Notification{
+				At:      now,
+				IssueID: id,
+				Title:   fmt.Sprintf("%s now matches %q", id, q),
+				Body:    issue.Title,
+				Watch:   w.String(),
+			})
+		}
+	}
+	return out
+}
+
+// Notifier delivers notifications through the configured channels.
+type Notifier struct {
+	cfg config.NotifyConfig
+	out io.Writer // terminal for bell/OSC sequences
+}
+
+// NewNotifier creates a notifier writing escape sequences to the terminal.
+func NewNotifier(cfg config.NotifyConfig) *Notifier {
+	return &Notifier{cfg: cfg, out: os.Stderr}
+}
+
+// Send delivers a notification. Each escape sequence is emitted in a single
+// write so it can't interleave with the TUI renderer's output.
+func (n *Notifier) Send(note Notification) error {
+	var seq strings.Builder
+	switch n.cfg.OSC {
+	case "9":
+		fmt.Fprintf(&seq, "\x1b]9;%s: %s\x07", sanitize(note.Title), sanitize(note.Body))
+	case "777":
+		fmt.Fprintf(&seq, "\x1b]777;notify;%s;%s\x07", sanitize(note.Title), sanitize(note.Body))
+	}
+	if n.cfg.Bell {
+		seq.WriteString("\a")
+	}
+	if seq.Len() > 0 {
+		if _, err := io.WriteString(n.out, seq.String()); err != nil {
+			return err
+		}
+	}
+
+	if n.cfg.Exec != "" {
+		return n.runHook(note)
+	}
+	return nil
+}
+
+// runHook runs the user's notification command through the shell.
+func (n *Notifier) runHook(note Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", n.cfg.Exec)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", n.cfg.Exec)
+	}
+	cmd.Env = append(os.Environ(),
+		"BDY_NOTIFY_TITLE="+note.Title,
+		"BDY_NOTIFY_BODY="+note.Body,
+		"BDY_NOTIFY_ISSUE="+note.IssueID,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify hook failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// sanitize strips characters that would terminate or corrupt an OSC string.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == ';' {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// Query is a parsed watch expression. All terms must match (logical AND).
+//
+// Supported terms:
+//
+//	ready             issue is in bd ready output
+//	pinned            issue is pinned
+//	overdue           due date has passed and issue is not closed
+//	status:<s>        exact status (open, in_progress, blocked, ...)
+//	priority:<n>      exact priority; "p0".."p4" is shorthand
+//	type:<t>          issue type (bug, feature, epic, ...)
+//	assignee:<name>   assignee (case-insensitive)
+//	label:<l>         has label
+//	<word>            title contains word (case-insensitive)
+type Query struct {
+	raw   string
+	terms []term
+}
+
+type term func(issue *models.Issue, ready bool) bool
+
+// ParseQuery parses a watch expression.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{raw: strings.TrimSpace(s)}
+	if q.raw == "" {
+		return nil, fmt.Errorf("empty query")
+	}
+	for _, tok := range strings.Fields(q.raw) {
+		t, err := parseTerm(tok)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// String returns the original expression.
+func (q *Query) String() string {
+	return q.raw
+}
+
+// Match reports whether an issue satisfies every term.
+func (q *Query) Match(issue *models.Issue, ready bool) bool {
+	for _, t := range q.terms {
+		if !t(issue, ready) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseTerm(tok string) (term, error) {
+	lower := strings.ToLower(tok)
+	switch lower {
+	case "ready":
+		return func(_ *models.Issue, ready bool) bool { return ready }, nil
+	case "pinned":
+		return func(i *models.Issue, _ bool) bool { return i.Pinned }, nil
+	case "overdue":
+		return func(i *models.Issue, _ bool) bool {
+			return i.IsOverdue(time.Now())
+		}, nil
+	}
+	if len(lower) == 2 && lower[0] == 'p' && lower[1] >= '0' && lower[1] <= '4' {
+		return parseTerm("priority:" + lower[1:])
+	}
+
+	key, value, ok := strings.Cut(tok, ":")
+	if !ok {
+		return func(i *models.Issue, _ bool) bool {
+			return strings.Contains(strings.ToLower(i.Title), lower)
+		}, nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value in %q", tok)
+	}
+	switch strings.ToLower(key) {
+	case "status":
+		return func(i *models.Issue, _ bool) bool { return i.Status == value }, nil
+	case "priority", "pri":
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "p"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %q", value)
+		}
+		return func(i *models.Issue, _ bool) bool { return i.Priority == n }, nil
+	case "type":
+		return func(i *models.Issue, _ bool) bool { return i.IssueType == value }, nil
+	case "assignee":
+		return func(i *models.Issue, _ bool) bool { return strings.EqualFold(i.Assignee, value) }, nil
+	case "label":
+		return func(i *models.Issue, _ bool) bool {
+			for _, l := range i.Labels {
+				if l == value {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown query field %q", key)
+	}
+}
@@ -0,0 +1,353 @@
+// Package search is an in-memory full-text index over issue bodies:
+// titles, descriptions, design, acceptance criteria, notes and comments.
+//
+// bd list omits long text and comments, so the index is fed full issues
+// from bd show and kept current by re-indexing issues whose UpdatedAt moved
+// (see Sync).
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// Field weights: a hit in the title says more than one in a comment.
+const (
+	weightTitle  = 3.0
+	weightLabels = 2.0
+	weightBody   = 1.0
+
+	// prefixDiscount scales hits on terms the query word only prefixes.
+	prefixDiscount = 0.5
+)
+
+// Index is an inverted index from terms to the issues containing them.
+type Index struct {
+	docs     map[string]*document
+	postings map[string]map[string]float64 // term -> issue ID -> weighted frequency
+	terms    []string                      // sorted keys of postings, for prefix lookups; nil when stale
+}
+
+type document struct {
+	id      string
+	title   string
+	updated time.Time
+	fields  []field
+	terms   map[string]float64
+}
+
+// field is one searchable piece of an issue.
+type field struct {
+	name   string
+	text   string
+	weight float64
+}
+
+// New creates an empty index.
+func New() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+// Len returns the number of indexed issues.
+func (x *Index) Len() int {
+	return len(x.docs)
+}
+
+// Sync reconciles the index with the current issue list: issues that are
+// gone are removed, and the IDs of issues that are new or whose UpdatedAt
+// moved since they were indexed are returned for (re)fetching.
+func (x *Index) Sync(issues []models.Issue) []string {
+	current := make(map[string]bool, len(issues))
+	var stale []string
+	for i := range issues {
+		current[issues[i].ID] = true
+		if doc, ok := x.docs[issues[i].ID]; !ok || !doc.updated.Equal(issues[i].UpdatedAt) {
+			stale = append(stale, issues[i].ID)
+		}
+	}
+	for id := range x.docs {
+		if !current[id] {
+			x.Remove(id)
+		}
+	}
+	return stale
+}
+
+// Add indexes a full issue (as returned by bd show), replacing any
+// previous version.
+func (x *Index) Add(issue *models.Issue) {
+	x.Remove(issue.ID)
+
+	fields := []field{
+		{"title", issue.Title, weightTitle},
+		{"labels", strings.Join(issue.Labels, " "), weightLabels},
+		{"description", issue.Description, weightBody},
+		{"design", issue.Design, weightBody},
+		{"acceptance", issue.AcceptanceCriteria, weightBody},
+		{"notes", issue.Notes, weightBody},
+	}
+	for _, c := range issue.Comments {
+		fields = append(fields, field{"comment by " + c.Author, c.Text, weightBody})
+		fields = append(fields, field{"comment author", c.Author, weightBody})
+	}
+
+	doc := &document{
+		id:      issue.ID,
+		title:   issue.Title,
+		updated: issue.UpdatedAt,
+		terms:   make(map[string]float64),
+	}
+	for _, f := range fields {
+		if f.text == "" {
+			continue
+		}
+		doc.fields = append(doc.fields, f)
+		for _, tok := range tokenize(f.text) {
+			doc.terms[tok.term] += f.weight
+		}
+	}
+	for _, tok := range tokenize(issue.ID) {
+		doc.terms[tok.term] += weightTitle
+	}
+
+	x.docs[issue.ID] = doc
+	for term, w := range doc.terms {
+		p := x.postings[term]
+		if p == nil {
+			p = make(map[string]float64)
+			x.postings[term] = p
+			x.terms = nil
+		}
+		p[issue.ID] = w
+	}
+}
+
+// Remove drops an issue from the index.
+func (x *Index) Remove(id string) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+			x.terms = nil
+		}
+	}
+	delete(x.docs, id)
+}
+
+// Span is a byte range within a snippet.
+type Span struct {
+	Start, End int
+}
+
+// Result is one ranked search hit.
+type Result struct {
+	ID      string
+	Title   string
+	Score   float64
+	Field   string // field the snippet was taken from
+	Snippet string // one line of context around the first hit
+	Spans   []Span // query-term hits within Snippet
+}
+
+// Search returns the issues containing every word of the query (the last
+// word also matches as a prefix, and so does any word of three or more
+// letters, at a discount), ranked by TF-IDF with a bonus for the exact
+// phrase. At most limit results are returned; limit <= 0 means all.
+func (x *Index) Search(query string, limit int) []Result {
+	words := tokenize(query)
+	if len(words) == 0 || len(x.docs) == 0 {
+		return nil
+	}
+
+	n := float64(len(x.docs))
+	scores := make(map[string]float64)
+	for wi, w := range words {
+		hits := make(map[string]float64)
+		for _, term := range x.expand(w.term, wi == len(words)-1) {
+			discount := 1.0
+			if term != w.term {
+				discount = prefixDiscount
+			}
+			idf := math.Log(1 + n/float64(len(x.postings[term])))
+			for id, tf := range x.postings[term] {
+				hits[id] += discount * tf * idf
+			}
+		}
+		// Every word must match.
+		if wi == 0 {
+			scores = hits
+			continue
+		}
+		for id := range scores {
+			if h, ok := hits[id]; ok {
+				scores[id] += h
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	phrase := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		doc := x.docs[id]
+		if len(words) > 1 {
+			for _, f := range doc.fields {
+				if strings.Contains(strings.ToLower(strings.Join(strings.Fields(f.text), " ")), phrase) {
+					score *= 1.5
+					break
+				}
+			}
+		}
+		r := Result{ID: id, Title: doc.title, Score: score}
+		r.Field, r.Snippet, r.Spans = doc.snippet(words)
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return x.docs[results[i].ID].updated.After(x.docs[results[j].ID].updated)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// expand returns the index terms a query word matches: the word itself,
+// plus terms it prefixes when prefix is set or the word is long enough to
+// be a useful stem.
+func (x *Index) expand(word string, prefix bool) []string {
+	var out []string
+	if _, ok := x.postings[word]; ok {
+		out = append(out, word)
+	}
+	if !prefix && utf8.RuneCountInString(word) < 3 {
+		return out
+	}
+	if x.terms == nil {
+		x.terms = make([]string, 0, len(x.postings))
+		for t := range x.postings {
+			x.terms = append(x.terms, t)
+		}
+		sort.Strings(x.terms)
+	}
+	for i := sort.SearchStrings(x.terms, word); i < len(x.terms) && strings.HasPrefix(x.terms[i], word); i++ {
+		if x.terms[i] != word {
+			out = append(out, x.terms[i])
+		}
+	}
+	return out
+}
+
+// Snippets keep snippetContext bytes before the first hit and are at most
+// snippetLength bytes long.
+const (
+	snippetContext = 40
+	snippetLength  = 200
+)
+
+// snippet picks the non-title field with the most query hits and returns
+// its name and a single line of text around the first hit, with every hit
+// marked.
+func (d *document) snippet(words []token) (string, string, []Span) {
+	best, bestHits := -1, 0
+	for i, f := range d.fields {
+		if hits := len(matchSpans(f.text, words)); f.name != "title" && hits > bestHits {
+			best, bestHits = i, hits
+		}
+	}
+	if best < 0 {
+		// Only the title (or ID) matched; it is shown anyway.
+		return "", "", nil
+	}
+	f := d.fields[best]
+	text := strings.Join(strings.Fields(f.text), " ")
+	spans := matchSpans(text, words)
+	start := 0
+	if len(spans) > 0 && spans[0].Start > snippetContext {
+		start = spans[0].Start - snippetContext
+		// Start on a word boundary.
+		if sp := strings.IndexByte(text[start:spans[0].Start], ' '); sp >= 0 {
+			start += sp + 1
+		}
+	}
+	end := min(len(text), start+snippetLength)
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = "…"
+	}
+	var out []Span
+	for _, s := range spans {
+		if s.Start >= start && s.End <= end {
+			out = append(out, Span{s.Start - start + len(prefix), s.End - start + len(prefix)})
+		}
+	}
+	return f.name, prefix + text[start:end], out
+}
+
+// matchSpans finds the tokens of text that a query word matches (exactly,
+// or as a prefix), returning their byte ranges.
+func matchSpans(text string, words []token) []Span {
+	var spans []Span
+	for _, tok := range tokenize(text) {
+		for _, w := range words {
+			if strings.HasPrefix(tok.term, w.term) {
+				spans = append(spans, Span{tok.start, tok.end})
+				break
+			}
+		}
+	}
+	return spans
+}
+
+// token is a lower-cased word and its byte range in the source text.
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text into lower-cased runs of letters and digits. Single
+// letters are dropped as noise; single digits are kept for IDs like bd-7.
+func tokenize(text string) []token {
+	var toks []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		if word := text[start:end]; utf8.RuneCountInString(word) > 1 || unicode.IsDigit(rune(word[0])) {
+			toks = append(toks, token{strings.ToLower(word), start, end})
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return toks
+}
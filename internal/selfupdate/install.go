@@ -0,0 +1,155 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+)
+
+// modulePath is the Go module bdy is built from.
+const modulePath = "github.com/" + repoOwner + "/" + repoName
+
+// installMethod is how the running binary was installed.
+type installMethod int
+
+const (
+	installDirect   installMethod = iota // a release binary bdy may replace
+	installHomebrew                      // a Homebrew cask or formula
+	installGo                            // go install into GOBIN or GOPATH/bin
+	installReadOnly                      // a directory the user can't write to
+)
+
+// install describes the running binary's installation.
+type install struct {
+	method installMethod
+	bin    string
+	cask   bool // Homebrew cask rather than formula
+}
+
+// detectInstall works out how bin was installed. Homebrew and go install
+// keep their own record of what they installed, which replacing the file
+// behind their back would leave stale.
+func detectInstall(bin string) install {
+	inst := install{method: installDirect, bin: bin}
+	slashed := filepath.ToSlash(bin)
+	switch {
+	case strings.Contains(slashed, "/Caskroom/"):
+		inst.method, inst.cask = installHomebrew, true
+	case strings.Contains(slashed, "/Cellar/"):
+		inst.method = installHomebrew
+	case inGoBin(bin) && builtFromModule():
+		inst.method = installGo
+	case !writable(filepath.Dir(bin)):
+		inst.method = installReadOnly
+	}
+	return inst
+}
+
+// inGoBin reports whether bin is in GOBIN or a GOPATH bin directory.
+func inGoBin(bin string) bool {
+	dirs := filepath.SplitList(build.Default.GOPATH)
+	for i := range dirs {
+		dirs[i] = filepath.Join(dirs[i], "bin")
+	}
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		dirs = append(dirs, gobin)
+	}
+	dir := filepath.Dir(bin)
+	for _, d := range dirs {
+		if resolved, err := filepath.EvalSymlinks(d); err == nil && resolved == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// builtFromModule reports whether the running binary was built as bdy's
+// main package by the go command.
+func builtFromModule() bool {
+	info, ok := debug.ReadBuildInfo()
+	return ok && info.Main.Path == modulePath && info.Path == modulePath+"/cmd/bdy"
+}
+
+// pseudoVersion matches the tail of a Go pseudo-version such as
+// v0.0.0-20260214120000-abcdef123456, which names a commit, not a release.
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}$`)
+
+// BuildVersion returns the running version: v, as set with -ldflags at
+// build time, or if that isn't a version, the release go install recorded
+// (go install doesn't set the -ldflags one). Builds of a commit or of a
+// modified checkout keep v, so they count as development builds.
+func BuildVersion(v string) string {
+	if IsVersion(v) {
+		return v
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path != modulePath {
+		return v
+	}
+	mv := info.Main.Version
+	if !IsVersion(mv) || strings.Contains(mv, "+") || pseudoVersion.MatchString(mv) {
+		return v
+	}
+	return mv
+}
+
+// writable reports whether files can be created in dir.
+func writable(dir string) bool {
+	f, err := os.CreateTemp(dir, ".bdy-write-test-*")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return true
+}
+
+// managedUpdate updates an installation bdy shouldn't replace itself:
+// it runs the package manager's upgrade if it can, and otherwise says
+// what to run. --force bypasses it.
+func managedUpdate(inst install, release *githubRelease, opts Options) error {
+	switch inst.method {
+	case installHomebrew:
+		// Homebrew only knows the latest stable release.
+		if opts.To != "" || channelName(opts.Channel) != ChannelStable {
+			return fmt.Errorf("bdy was installed with Homebrew, which only installs the latest stable release; use --force to replace %s anyway", inst.bin)
+		}
+		args := []string{"upgrade", "bdy"}
+		if inst.cask {
+			args = []string{"upgrade", "--cask", repoOwner + "/tap/bdy"}
+		}
+		return runUpgrade("Homebrew", "brew", args...)
+	case installGo:
+		return runUpgrade("go install", "go", "install", fmt.Sprintf("%s/cmd/bdy@%s", modulePath, release.TagName))
+	case installReadOnly:
+		return fmt.Errorf("%s is not writable; rerun with sudo, or reinstall bdy somewhere you can write to", filepath.Dir(inst.bin))
+	}
+	return nil
+}
+
+// runUpgrade runs a package manager's upgrade command, or prints it if
+// the package manager isn't on PATH.
+func runUpgrade(manager, name string, args ...string) error {
+	command := name + " " + strings.Join(args, " ")
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("bdy was installed with %s; run `%s` to update it, or use --force to replace it directly", manager, command)
+	}
+	fmt.Printf("bdy was installed with %s; running %s\n", manager, command)
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s failed (exit %d)", command, exitErr.ExitCode())
+		}
+		return fmt.Errorf("%s failed: %w", command, err)
+	}
+	return nil
+}
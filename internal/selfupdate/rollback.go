@@ -0,0 +1,76 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// backupPath is where Update keeps the binary it replaced.
+func backupPath(bin string) string {
+	return bin + ".prev"
+}
+
+// currentBinary returns the running binary's path with symlinks resolved.
+func currentBinary() (string, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding current binary: %w", err)
+	}
+	bin, err = filepath.EvalSymlinks(bin)
+	if err != nil {
+		return "", fmt.Errorf("resolving symlinks: %w", err)
+	}
+	return bin, nil
+}
+
+// Rollback swaps the running binary with the one the last update
+// replaced. The swap keeps the newer binary as the backup, so rolling
+// back again re-applies the update. Like Update, it leaves installs a
+// package manager owns alone unless opts.Force is set.
+func Rollback(opts Options) error {
+	currentBin, err := currentBinary()
+	if err != nil {
+		return err
+	}
+	backup := backupPath(currentBin)
+	if _, err := os.Stat(backup); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no previous version to roll back to (%s not found)", backup)
+	} else if err != nil {
+		return err
+	}
+	switch inst := detectInstall(currentBin); inst.method {
+	case installHomebrew, installGo:
+		if !opts.Force {
+			manager := "Homebrew"
+			if inst.method == installGo {
+				manager = "go install"
+			}
+			return fmt.Errorf("bdy was installed with %s, which would lose track of the installed version; use --force to roll back anyway", manager)
+		}
+	case installReadOnly:
+		return fmt.Errorf("%s is not writable; rerun with sudo", filepath.Dir(currentBin))
+	}
+
+	tmp := currentBin + ".rollback"
+	if err := os.Rename(currentBin, tmp); err != nil {
+		return fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(backup, currentBin); err != nil {
+		os.Rename(tmp, currentBin)
+		return fmt.Errorf("restoring previous binary: %w", err)
+	}
+	if err := os.Rename(tmp, backup); err != nil {
+		return fmt.Errorf("keeping the replaced binary: %w", err)
+	}
+
+	version := "the previous version"
+	if out, err := exec.Command(currentBin, "version").Output(); err == nil {
+		version = strings.TrimSpace(string(out))
+	}
+	fmt.Printf("Rolled back to %s. Run `bdy update --rollback` again to undo.\n", version)
+	return nil
+}
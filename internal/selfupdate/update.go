@@ -1,7 +1,10 @@
 package selfupdate
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,11 +18,52 @@ import (
 const (
 	repoOwner = "poiley"
 	repoName  = "beady"
+
+	// defaultBaseURL is the GitHub API the release is looked up in.
+	defaultBaseURL = "https://api.github.com"
+
+	// maxSmallAsset caps the size of checksum and signature downloads.
+	maxSmallAsset = 1 << 20
+)
+
+// Release channels for Options.Channel.
+const (
+	ChannelStable     = "stable"     // the latest release
+	ChannelPrerelease = "prerelease" // the newest release, prereleases included
 )
 
+// Options configure an update.
+type Options struct {
+	// BaseURL replaces the GitHub API base URL, so the whole flow can run
+	// against a local stand-in. Empty means api.github.com.
+	BaseURL string
+
+	// Verify additionally checks the signature on checksums.txt:
+	// VerifyCosign, VerifyMinisign, or "" for the checksum alone.
+	Verify string
+
+	// Key is the public key for Verify. For minisign it is the key or a
+	// key file; for cosign, a key file or reference, or empty to verify a
+	// keyless signature from this repository's release workflow.
+	Key string
+
+	// Channel picks the release to update to: ChannelStable (also "") or
+	// ChannelPrerelease.
+	Channel string
+
+	// To pins the update to a release tag such as v1.2.0, ignoring the
+	// channel. It may be older than the running version.
+	To string
+
+	// Force replaces the binary even if Homebrew or go install manages
+	// it, instead of handing the update to them.
+	Force bool
+}
+
 // githubRelease represents a GitHub release API response.
 type githubRelease struct {
 	TagName string  `json:"tag_name"`
+	Draft   bool    `json:"draft"`
 	Assets  []asset `json:"assets"`
 }
 
@@ -28,65 +72,180 @@ type asset struct {
 	BrowserDownloadURL string `json:"browser_download_url"`
 }
 
-// Update checks for a newer version and installs it.
-func Update(currentVersion string) error {
+// Update installs the newest release on opts.Channel if it is newer than
+// currentVersion, or the release opts.To names. The archive's SHA-256
+// must match the release's checksums.txt, and its signature must verify
+// if opts.Verify asks for one; otherwise nothing is installed. The
+// binary being replaced is kept for Rollback. Installs that a package
+// manager owns are updated through it unless opts.Force is set.
+func Update(currentVersion string, opts Options) error {
+	currentBin, err := currentBinary()
+	if err != nil {
+		return err
+	}
+	return update(currentVersion, currentBin, opts)
+}
+
+// update is Update for the binary at currentBin.
+func update(currentVersion, currentBin string, opts Options) error {
+	inst := detectInstall(currentBin)
+	currentVersion = BuildVersion(currentVersion)
+
 	fmt.Println("Checking for updates...")
 
-	release, err := getLatestRelease()
+	release, err := findRelease(opts)
 	if err != nil {
 		return fmt.Errorf("checking for updates: %w", err)
 	}
 
 	latestVersion := strings.TrimPrefix(release.TagName, "v")
 	currentClean := strings.TrimPrefix(currentVersion, "v")
+	latest, ok := parseVersion(latestVersion)
+	if !ok {
+		return fmt.Errorf("release %s is not a semantic version", release.TagName)
+	}
+	current, isRelease := parseVersion(currentClean)
 
-	if latestVersion == currentClean {
-		fmt.Printf("Already up to date (v%s).\n", currentClean)
+	switch {
+	case isRelease && latest.compare(current) == 0:
+		fmt.Printf("Already at v%s.\n", currentClean)
 		return nil
+	case opts.To != "":
+		fmt.Printf("Installing v%s (running %s)\n", latestVersion, currentVersion)
+	case !isRelease:
+		return fmt.Errorf("running a development build (%s); use --to %s to replace it with a release", currentVersion, release.TagName)
+	case latest.compare(current) < 0:
+		fmt.Printf("Already up to date: v%s is newer than the latest %s release (v%s).\n", currentClean, channelName(opts.Channel), latestVersion)
+		return nil
+	default:
+		fmt.Printf("New version available: v%s -> v%s\n", currentClean, latestVersion)
 	}
 
-	fmt.Printf("New version available: v%s -> v%s\n", currentClean, latestVersion)
-
-	// Find the right asset for this OS/arch
-	assetName := getAssetName()
-	var downloadURL string
-	for _, a := range release.Assets {
-		if a.Name == assetName {
-			downloadURL = a.BrowserDownloadURL
-			break
+	if inst.method != installDirect {
+		if !opts.Force {
+			return managedUpdate(inst, release, opts)
 		}
+		fmt.Printf("Replacing %s directly (--force).\n", currentBin)
 	}
 
-	if downloadURL == "" {
+	// Find the right asset for this OS/arch
+	assetName := getAssetName()
+	if release.assetURL(assetName) == "" {
 		// Fallback: try go install
 		fmt.Println("No pre-built binary found for your platform. Trying go install...")
 		return goInstall(release.TagName)
 	}
 
-	// Download and replace
-	return downloadAndInstall(downloadURL, latestVersion)
+	// Download, verify and replace
+	return downloadAndInstall(release, assetName, latestVersion, currentBin, opts)
+}
+
+// Latest returns the tag of the newest release on opts.Channel, without
+// printing anything, for background update checks.
+func Latest(opts Options) (string, error) {
+	opts.To = ""
+	release, err := findRelease(opts)
+	if err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// assetURL returns the download URL of the named asset, or "".
+func (r *githubRelease) assetURL(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.BrowserDownloadURL
+		}
+	}
+	return ""
+}
+
+// channelName names a channel, defaulting to stable.
+func channelName(channel string) string {
+	if channel == "" {
+		return ChannelStable
+	}
+	return channel
 }
 
-func getLatestRelease() (*githubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
+// findRelease looks up the release opts asks for: the tag in opts.To, or
+// the newest on opts.Channel.
+func findRelease(opts Options) (*githubRelease, error) {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", strings.TrimSuffix(baseURL, "/"), repoOwner, repoName)
+
+	if opts.To != "" {
+		tag := "v" + strings.TrimPrefix(opts.To, "v")
+		var release githubRelease
+		if err := getJSON(repoURL+"/releases/tags/"+tag, &release); err != nil {
+			if errors.Is(err, errNotFound) {
+				return nil, fmt.Errorf("no release %s in %s/%s", tag, repoOwner, repoName)
+			}
+			return nil, err
+		}
+		return &release, nil
+	}
+
+	switch channelName(opts.Channel) {
+	case ChannelStable:
+		// GitHub's latest release skips drafts and prereleases.
+		var release githubRelease
+		if err := getJSON(repoURL+"/releases/latest", &release); err != nil {
+			if errors.Is(err, errNotFound) {
+				return nil, fmt.Errorf("no releases found. Repository %s/%s may not have any releases yet", repoOwner, repoName)
+			}
+			return nil, err
+		}
+		return &release, nil
+	case ChannelPrerelease:
+		// The newest by version rather than by date, so a patch to an
+		// older line doesn't win over a newer prerelease.
+		var releases []githubRelease
+		if err := getJSON(repoURL+"/releases?per_page=30", &releases); err != nil {
+			return nil, err
+		}
+		var newest *githubRelease
+		var newestVersion version
+		for i := range releases {
+			v, ok := parseVersion(releases[i].TagName)
+			if releases[i].Draft || !ok {
+				continue
+			}
+			if newest == nil || v.compare(newestVersion) > 0 {
+				newest, newestVersion = &releases[i], v
+			}
+		}
+		if newest == nil {
+			return nil, fmt.Errorf("no releases found. Repository %s/%s may not have any releases yet", repoOwner, repoName)
+		}
+		return newest, nil
+	default:
+		return nil, fmt.Errorf("unknown channel %q (use %s or %s)", opts.Channel, ChannelStable, ChannelPrerelease)
+	}
+}
+
+// errNotFound is returned by getJSON for a 404.
+var errNotFound = errors.New("not found")
+
+// getJSON decodes a GitHub API response into v.
+func getJSON(url string, v any) error {
 	resp, err := http.Get(url)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("no releases found. Repository %s/%s may not have any releases yet", repoOwner, repoName)
+		return errNotFound
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API returned %d", resp.StatusCode)
-	}
-
-	var release githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, err
+		return fmt.Errorf("GitHub API returned %d", resp.StatusCode)
 	}
-	return &release, nil
+	return json.NewDecoder(resp.Body).Decode(v)
 }
 
 func getAssetName() string {
@@ -97,10 +256,44 @@ func getAssetName() string {
 	return fmt.Sprintf("bdy_%s_%s%s", runtime.GOOS, runtime.GOARCH, ext)
 }
 
-func downloadAndInstall(url, version string) error {
+// downloadAsset fetches a small release asset, such as the checksums or
+// a signature, into memory.
+func downloadAsset(release *githubRelease, name string) ([]byte, error) {
+	url := release.assetURL(name)
+	if url == "" {
+		return nil, fmt.Errorf("no %s in release %s", name, release.TagName)
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("downloading %s: HTTP %d", name, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSmallAsset))
+}
+
+func downloadAndInstall(release *githubRelease, assetName, version, currentBin string, opts Options) error {
+	// Establish what the archive should hash to before fetching it.
+	checksums, err := downloadAsset(release, checksumsName)
+	if err != nil {
+		return fmt.Errorf("refusing to install without a checksum: %w", err)
+	}
+	if opts.Verify != "" {
+		fmt.Printf("Verifying %s signature...\n", opts.Verify)
+		if err := verifySignature(release, checksums, opts); err != nil {
+			return fmt.Errorf("refusing to install: %w", err)
+		}
+	}
+	want, err := expectedChecksum(checksums, assetName)
+	if err != nil {
+		return fmt.Errorf("refusing to install: %w", err)
+	}
+
 	fmt.Printf("Downloading v%s...\n", version)
 
-	resp, err := http.Get(url)
+	resp, err := http.Get(release.assetURL(assetName))
 	if err != nil {
 		return fmt.Errorf("downloading: %w", err)
 	}
@@ -110,19 +303,25 @@ func downloadAndInstall(url, version string) error {
 		return fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
 	}
 
-	// Write to temp file
+	// Write to temp file, hashing as we go
 	tmpFile, err := os.CreateTemp("", "bdy-update-*")
 	if err != nil {
 		return fmt.Errorf("creating temp file: %w", err)
 	}
 	defer os.Remove(tmpFile.Name())
 
-	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, hash), resp.Body); err != nil {
 		tmpFile.Close()
 		return fmt.Errorf("downloading: %w", err)
 	}
 	tmpFile.Close()
 
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s; refusing to install", assetName, want, got)
+	}
+	fmt.Println("Checksum verified.")
+
 	// Extract the binary
 	binPath, extractDir, err := extractBinary(tmpFile.Name())
 	if err != nil {
@@ -130,22 +329,15 @@ func downloadAndInstall(url, version string) error {
 	}
 	defer os.RemoveAll(extractDir)
 
-	// Find current binary location
-	currentBin, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("finding current binary: %w", err)
+	// Keep the current binary for rollback, then replace it
+	if err := copyFile(currentBin, backupPath(currentBin)); err != nil {
+		return fmt.Errorf("backing up current binary: %w", err)
 	}
-	currentBin, err = filepath.EvalSymlinks(currentBin)
-	if err != nil {
-		return fmt.Errorf("resolving symlinks: %w", err)
-	}
-
-	// Replace current binary
 	if err := replaceBinary(binPath, currentBin); err != nil {
 		return fmt.Errorf("replacing binary: %w", err)
 	}
 
-	fmt.Printf("Updated to v%s.\n", version)
+	fmt.Printf("Updated to v%s. `bdy update --rollback` restores the previous version.\n", version)
 	return nil
 }
 
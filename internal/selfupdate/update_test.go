@@ -0,0 +1,293 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	oldBinary = "old bdy"
+	newBinary = "new bdy"
+)
+
+// fakeRelease stands in for the GitHub API and release downloads. Each
+// file becomes an asset of release v9.9.9.
+func fakeRelease(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	release := githubRelease{TagName: "v9.9.9"}
+	for name := range files {
+		release.Assets = append(release.Assets, asset{Name: name, BrowserDownloadURL: srv.URL + "/download/" + name})
+	}
+	mux.HandleFunc("/repos/"+repoOwner+"/"+repoName+"/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(release)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/download/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	})
+	return srv
+}
+
+// archive returns a release tarball holding a bdy binary with contents.
+func archive(t *testing.T, contents string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "bdy", Mode: 0o755, Size: int64(len(contents))}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write([]byte(contents))
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func sha(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// installed creates a binary to update, returning its path.
+func installed(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("release archives are zips on Windows")
+	}
+	bin := filepath.Join(t.TempDir(), "bdy")
+	if err := os.WriteFile(bin, []byte(oldBinary), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return bin
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// fakeTool puts a script named name on PATH that exits with code and
+// appends its arguments to the returned log file.
+func fakeTool(t *testing.T, name string, code int) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\nexit " + strconv.Itoa(code) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestUpdateVerifiesChecksum(t *testing.T) {
+	bin := installed(t)
+	tarball := archive(t, newBinary)
+	name := getAssetName()
+	srv := fakeRelease(t, map[string][]byte{
+		name:          tarball,
+		checksumsName: []byte(sha(tarball) + "  " + name + "\n" + sha([]byte("other")) + "  bdy_other.tar.gz\n"),
+	})
+
+	if err := update("1.0.0", bin, Options{BaseURL: srv.URL}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := readFile(t, bin); got != newBinary {
+		t.Errorf("binary = %q, want %q", got, newBinary)
+	}
+	if got := readFile(t, backupPath(bin)); got != oldBinary {
+		t.Errorf("backup = %q, want %q", got, oldBinary)
+	}
+}
+
+func TestUpdateRefusesUnverifiedArchive(t *testing.T) {
+	tarball := archive(t, newBinary)
+	name := getAssetName()
+	tests := []struct {
+		name    string
+		files   map[string][]byte
+		opts    Options
+		wantErr string
+	}{
+		{
+			name: "checksum mismatch",
+			files: map[string][]byte{
+				name:          tarball,
+				checksumsName: []byte(sha([]byte("tampered")) + "  " + name + "\n"),
+			},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "no checksums file",
+			files:   map[string][]byte{name: tarball},
+			wantErr: "refusing to install without a checksum",
+		},
+		{
+			name: "no entry for the archive",
+			files: map[string][]byte{
+				name:          tarball,
+				checksumsName: []byte(sha(tarball) + "  bdy_other.tar.gz\n"),
+			},
+			wantErr: "has no entry for " + name,
+		},
+		{
+			name: "malformed entry",
+			files: map[string][]byte{
+				name:          tarball,
+				checksumsName: []byte("not-hex  " + name + "\n"),
+			},
+			wantErr: "malformed checksum",
+		},
+		{
+			name: "signature missing",
+			files: map[string][]byte{
+				name:          tarball,
+				checksumsName: []byte(sha(tarball) + "  " + name + "\n"),
+			},
+			opts:    Options{Verify: VerifyMinisign, Key: "RWQkey"},
+			wantErr: "not signed for minisign",
+		},
+		{
+			name: "minisign without a key",
+			files: map[string][]byte{
+				name:                       tarball,
+				checksumsName:              []byte(sha(tarball) + "  " + name + "\n"),
+				checksumsName + ".minisig": []byte("sig"),
+			},
+			opts:    Options{Verify: VerifyMinisign},
+			wantErr: "needs a public key",
+		},
+		{
+			name: "unknown signature scheme",
+			files: map[string][]byte{
+				name:          tarball,
+				checksumsName: []byte(sha(tarball) + "  " + name + "\n"),
+			},
+			opts:    Options{Verify: "gpg"},
+			wantErr: "unknown signature scheme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bin := installed(t)
+			opts := tt.opts
+			opts.BaseURL = fakeRelease(t, tt.files).URL
+
+			err := update("1.0.0", bin, opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("update error = %v, want one containing %q", err, tt.wantErr)
+			}
+			if got := readFile(t, bin); got != oldBinary {
+				t.Errorf("binary replaced with %q despite the error", got)
+			}
+		})
+	}
+}
+
+func TestUpdateVerifiesSignature(t *testing.T) {
+	tarball := archive(t, newBinary)
+	name := getAssetName()
+	files := map[string][]byte{
+		name:                       tarball,
+		checksumsName:              []byte(sha(tarball) + "  " + name + "\n"),
+		checksumsName + ".minisig": []byte("minisig"),
+		checksumsName + ".sig":     []byte("sig"),
+		checksumsName + ".pem":     []byte("cert"),
+	}
+	tests := []struct {
+		name     string
+		tool     string
+		code     int
+		opts     Options
+		wantErr  string
+		wantArgs []string
+	}{
+		{
+			name:     "minisign good",
+			tool:     "minisign",
+			opts:     Options{Verify: VerifyMinisign, Key: "RWQkey"},
+			wantArgs: []string{"-V", "-P RWQkey", checksumsName + ".minisig"},
+		},
+		{
+			name:    "minisign bad",
+			tool:    "minisign",
+			code:    1,
+			opts:    Options{Verify: VerifyMinisign, Key: "RWQkey"},
+			wantErr: "minisign signature verification failed",
+		},
+		{
+			name:     "cosign keyless good",
+			tool:     "cosign",
+			opts:     Options{Verify: VerifyCosign},
+			wantArgs: []string{"verify-blob", "--certificate-identity-regexp " + cosignIdentity, "--certificate-oidc-issuer " + cosignIssuer},
+		},
+		{
+			name:     "cosign key good",
+			tool:     "cosign",
+			opts:     Options{Verify: VerifyCosign, Key: "cosign.pub"},
+			wantArgs: []string{"verify-blob", "--key cosign.pub"},
+		},
+		{
+			name:    "cosign bad",
+			tool:    "cosign",
+			code:    1,
+			opts:    Options{Verify: VerifyCosign, Key: "cosign.pub"},
+			wantErr: "cosign signature verification failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bin := installed(t)
+			log := fakeTool(t, tt.tool, tt.code)
+			opts := tt.opts
+			opts.BaseURL = fakeRelease(t, files).URL
+
+			err := update("1.0.0", bin, opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("update error = %v, want one containing %q", err, tt.wantErr)
+				}
+				if got := readFile(t, bin); got != oldBinary {
+					t.Errorf("binary replaced with %q despite a bad signature", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got := readFile(t, bin); got != newBinary {
+				t.Errorf("binary = %q, want %q", got, newBinary)
+			}
+			args := readFile(t, log)
+			for _, want := range tt.wantArgs {
+				if !strings.Contains(args, want) {
+					t.Errorf("%s args %q lack %q", tt.tool, args, want)
+				}
+			}
+		})
+	}
+}
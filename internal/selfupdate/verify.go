@@ -0,0 +1,116 @@
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// checksumsName is the checksum file goreleaser publishes with each release.
+const checksumsName = "checksums.txt"
+
+// Signature schemes accepted for Options.Verify.
+const (
+	VerifyCosign   = "cosign"
+	VerifyMinisign = "minisign"
+)
+
+// Keyless cosign signatures must come from this repository's GitHub
+// Actions workflows.
+const (
+	cosignIdentity = "^https://github.com/" + repoOwner + "/" + repoName + "/"
+	cosignIssuer   = "https://token.actions.githubusercontent.com"
+)
+
+// expectedChecksum finds the SHA-256 of name in a goreleaser checksums
+// file ("<hex>  <name>" per line).
+func expectedChecksum(checksums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(checksums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			sum := strings.ToLower(fields[0])
+			if _, err := hex.DecodeString(sum); err != nil || len(sum) != 2*sha256.Size {
+				return "", fmt.Errorf("malformed checksum for %s in %s", name, checksumsName)
+			}
+			return sum, nil
+		}
+	}
+	return "", fmt.Errorf("%s has no entry for %s", checksumsName, name)
+}
+
+// verifySignature checks the signature on checksums.txt with the scheme
+// in opts.Verify, using the cosign or minisign CLI. It fails if the
+// release has no matching signature asset.
+func verifySignature(release *githubRelease, checksums []byte, opts Options) error {
+	dir, err := os.MkdirTemp("", "bdy-verify-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	sumsPath := filepath.Join(dir, checksumsName)
+	if err := os.WriteFile(sumsPath, checksums, 0o644); err != nil {
+		return err
+	}
+	// fetch saves a signature asset next to the checksums file.
+	fetch := func(name string) (string, error) {
+		data, err := downloadAsset(release, name)
+		if err != nil {
+			return "", fmt.Errorf("release is not signed for %s (%s: %w)", opts.Verify, name, err)
+		}
+		path := filepath.Join(dir, name)
+		return path, os.WriteFile(path, data, 0o644)
+	}
+
+	var cmd *exec.Cmd
+	switch opts.Verify {
+	case VerifyCosign:
+		sig, err := fetch(checksumsName + ".sig")
+		if err != nil {
+			return err
+		}
+		args := []string{"verify-blob", "--signature", sig}
+		if opts.Key != "" {
+			args = append(args, "--key", opts.Key)
+		} else {
+			cert, err := fetch(checksumsName + ".pem")
+			if err != nil {
+				return err
+			}
+			args = append(args, "--certificate", cert,
+				"--certificate-identity-regexp", cosignIdentity,
+				"--certificate-oidc-issuer", cosignIssuer)
+		}
+		cmd = exec.Command("cosign", append(args, sumsPath)...)
+	case VerifyMinisign:
+		if opts.Key == "" {
+			return fmt.Errorf("minisign verification needs a public key (--key)")
+		}
+		sig, err := fetch(checksumsName + ".minisig")
+		if err != nil {
+			return err
+		}
+		keyFlag := "-P" // the key itself
+		if _, err := os.Stat(opts.Key); err == nil {
+			keyFlag = "-p" // a key file
+		}
+		cmd = exec.Command("minisign", "-V", keyFlag, opts.Key, "-m", sumsPath, "-x", sig)
+	default:
+		return fmt.Errorf("unknown signature scheme %q (use %s or %s)", opts.Verify, VerifyCosign, VerifyMinisign)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if _, lookErr := exec.LookPath(cmd.Args[0]); lookErr != nil {
+			return fmt.Errorf("%s is not installed: %w", cmd.Args[0], lookErr)
+		}
+		return fmt.Errorf("%s signature verification failed: %s", opts.Verify, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
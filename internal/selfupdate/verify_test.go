@@ -0,0 +1,47 @@
+package selfupdate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpectedChecksum(t *testing.T) {
+	const sum = "f1454bdacaa9f3cf43dcfc02e7754a1d53021f72add2c9547d296b731ff6b504"
+	tests := []struct {
+		name      string
+		checksums string
+		want      string
+		wantErr   string
+	}{
+		{name: "found", checksums: sum + "  bdy_linux_amd64.tar.gz\n", want: sum},
+		{name: "binary mode marker", checksums: sum + " *bdy_linux_amd64.tar.gz\n", want: sum},
+		{name: "upper case", checksums: strings.ToUpper(sum) + "  bdy_linux_amd64.tar.gz\n", want: sum},
+		{
+			name:      "among others",
+			checksums: strings.Repeat("0", 64) + "  bdy_darwin_arm64.tar.gz\n" + sum + "  bdy_linux_amd64.tar.gz\n",
+			want:      sum,
+		},
+		{name: "missing", checksums: sum + "  bdy_darwin_arm64.tar.gz\n", wantErr: "has no entry"},
+		{name: "prefix of another name", checksums: sum + "  bdy_linux_amd64.tar.gz.sbom\n", wantErr: "has no entry"},
+		{name: "empty", checksums: "", wantErr: "has no entry"},
+		{name: "not hex", checksums: strings.Repeat("z", 64) + "  bdy_linux_amd64.tar.gz\n", wantErr: "malformed"},
+		{name: "too short", checksums: sum[:40] + "  bdy_linux_amd64.tar.gz\n", wantErr: "malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expectedChecksum([]byte(tt.checksums), "bdy_linux_amd64.tar.gz")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("checksum = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package selfupdate
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed semantic version. Build metadata is dropped since
+// it doesn't affect precedence.
+type version struct {
+	major, minor, patch int
+	pre                 []string // prerelease identifiers, nil for a release
+}
+
+// parseVersion parses "1.2.3", "v1.2.3-rc.1" or "1.2.3+meta". It reports
+// false for anything else, such as a "dev" build.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return version{}, false
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		nums[i] = n
+	}
+	v := version{major: nums[0], minor: nums[1], patch: nums[2]}
+	if hasPre {
+		if pre == "" {
+			return version{}, false
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+	return v, true
+}
+
+// prerelease reports whether v is a prerelease such as 1.3.0-rc.1.
+func (v version) prerelease() bool {
+	return len(v.pre) > 0
+}
+
+// compare returns -1, 0 or 1 as v sorts before, equal to or after w,
+// following semver precedence.
+func (v version) compare(w version) int {
+	for _, d := range [][2]int{{v.major, w.major}, {v.minor, w.minor}, {v.patch, w.patch}} {
+		if c := cmp.Compare(d[0], d[1]); c != 0 {
+			return c
+		}
+	}
+	// A release sorts after its prereleases.
+	switch {
+	case !v.prerelease() && !w.prerelease():
+		return 0
+	case !v.prerelease():
+		return 1
+	case !w.prerelease():
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(w.pre); i++ {
+		if c := comparePre(v.pre[i], w.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.pre), len(w.pre))
+}
+
+// comparePre compares prerelease identifiers: numeric ones numerically
+// and below alphanumeric ones, which compare as strings.
+func comparePre(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Newer reports whether latest is a newer version than current. It is
+// false when either doesn't parse, so a development build never sees an
+// update it didn't ask for.
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	return ok && l.compare(c) > 0
+}
+
+// IsVersion reports whether v is a semantic version, as release builds
+// are, rather than something like "dev".
+func IsVersion(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
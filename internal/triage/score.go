@@ -0,0 +1,124 @@
+package triage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/poiley/beady/internal/models"
+)
+
+// Weights scales each scoring factor. Every factor is normalized to [0, 1]
+// before weighting, so a weight is the most points that factor can add.
+type Weights struct {
+	Priority float64 `json:"priority"`
+	Due      float64 `json:"due"`
+	Age      float64 `json:"age"`
+	Unblocks float64 `json:"unblocks"`
+	Assignee float64 `json:"assignee"`
+}
+
+// DefaultWeights favors priority, then deadlines and unblocking others.
+func DefaultWeights() Weights {
+	return Weights{Priority: 10, Due: 5, Age: 2, Unblocks: 4, Assignee: 3}
+}
+
+const (
+	dueHorizon     = 14 * 24 * time.Hour // due dates further out score zero
+	ageHorizon     = 30 * 24 * time.Hour // age stops adding points after this
+	unblocksCap    = 5                   // dependents beyond this add nothing
+	lowestPriority = 4
+)
+
+// Reason is one factor's contribution to a score.
+type Reason struct {
+	Label  string  // e.g. "P1", "due in 2d", "unblocks 3"
+	Points float64 // weighted contribution
+}
+
+func (r Reason) String() string {
+	return fmt.Sprintf("%s +%.1f", r.Label, r.Points)
+}
+
+// Scored is a ready issue with its score and the reasoning behind it.
+type Scored struct {
+	Issue   models.Issue
+	Score   float64
+	Reasons []Reason
+}
+
+// Rank scores the ready issues and returns them best first. all is the full
+// issue list, used to count the open issues each candidate blocks; me is
+// the current user for the assignee factor.
+func Rank(ready, all []models.Issue, w Weights, me string, now time.Time) []Scored {
+	blocks := unblockCounts(all)
+
+	scored := make([]Scored, 0, len(ready))
+	for _, issue := range ready {
+		s := Scored{Issue: issue}
+		add := func(label string, weight, factor float64) {
+			if weight == 0 || factor <= 0 {
+				return
+			}
+			pts := weight * math.Min(factor, 1)
+			s.Score += pts
+			s.Reasons = append(s.Reasons, Reason{Label: label, Points: pts})
+		}
+
+		pri := min(max(issue.Priority, 0), lowestPriority)
+		add(issue.PriorityString(), w.Priority, float64(lowestPriority-pri)/lowestPriority)
+
+		if issue.DueAt != nil {
+			until := issue.DueAt.Sub(now)
+			if until <= 0 {
+				add("overdue", w.Due, 1)
+			} else if until < dueHorizon {
+				add("due in "+models.RelativeAge(*issue.DueAt), w.Due, 1-float64(until)/float64(dueHorizon))
+			}
+		}
+
+		if n := blocks[issue.ID]; n > 0 {
+			add(fmt.Sprintf("unblocks %d", n), w.Unblocks, float64(n)/unblocksCap)
+		}
+
+		if age := now.Sub(issue.CreatedAt); age > 0 {
+			add("age "+models.RelativeAge(issue.CreatedAt), w.Age, float64(age)/float64(ageHorizon))
+		}
+
+		switch {
+		case me != "" && issue.Assignee == me:
+			add("mine", w.Assignee, 1)
+		case issue.Assignee == "":
+			add("unassigned", w.Assignee, 0.5)
+		}
+
+		scored = append(scored, s)
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Issue.ID < scored[j].Issue.ID
+	})
+	return scored
+}
+
+// unblockCounts returns, per issue ID, how many non-closed issues depend on
+// it through a "blocks" dependency. Parent-child links are not counted: an
+// epic isn't waiting on a child in the same sense.
+func unblockCounts(all []models.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range all {
+		if issue.Status == "closed" {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.DepTypeValue() == "blocks" {
+				counts[dep.ParentID()]++
+			}
+		}
+	}
+	return counts
+}
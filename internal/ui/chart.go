@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line bar sparkline, one cell per value.
+// If there are more values than width, only the most recent are shown.
+func Sparkline(values []int, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if peak == 0 || v <= 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		i := int(math.Round(float64(v) / float64(peak) * float64(len(sparkTicks)-1)))
+		b.WriteRune(sparkTicks[min(max(i, 0), len(sparkTicks)-1)])
+	}
+	return b.String()
+}
+
+var barEighths = []rune(" ▏▎▍▌▋▊▉")
+
+// HBar renders a horizontal bar of value/peak scaled to width cells, with
+// eighth-cell precision.
+func HBar(value, peak, width int) string {
+	if peak <= 0 || value <= 0 || width <= 0 {
+		return ""
+	}
+	eighths := int(math.Round(float64(value) / float64(peak) * float64(width*8)))
+	eighths = max(eighths, 1)
+	full, rem := eighths/8, eighths%8
+	s := strings.Repeat("█", full)
+	if rem > 0 {
+		s += string(barEighths[rem])
+	}
+	return s
+}
+
+// BarPart is one colored segment of a stacked bar.
+type BarPart struct {
+	Value int
+	Color lipgloss.Color
+}
+
+// StackedBar renders parts proportionally across width cells. Every
+// non-zero part gets at least one cell.
+func StackedBar(parts []BarPart, width int) string {
+	total := 0
+	for _, p := range parts {
+		total += p.Value
+	}
+	if total == 0 || width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	used := 0
+	for i, p := range parts {
+		if p.Value == 0 {
+			continue
+		}
+		n := int(math.Round(float64(p.Value) / float64(total) * float64(width)))
+		n = max(n, 1)
+		if i == len(parts)-1 || used+n > width {
+			n = max(0, width-used)
+		}
+		used += n
+		b.WriteString(lipgloss.NewStyle().Foreground(p.Color).Render(strings.Repeat("█", n)))
+	}
+	return b.String()
+}
+
+// Series is one line on a LineChart.
+type Series struct {
+	Values []float64
+	Color  lipgloss.Color
+}
+
+// brailleDots maps (x, y) within a 2x4 cell to its braille dot bit.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// LineChart plots series on a braille canvas of width x height cells (each
+// cell is 2x4 dots), with a y-axis label column on the left. All series
+// share the x axis (index) and a y axis from 0 to the overall peak. Returns
+// height lines.
+func LineChart(series []Series, width, height int) []string {
+	peak := 0.0
+	points := 0
+	for _, s := range series {
+		points = max(points, len(s.Values))
+		for _, v := range s.Values {
+			peak = math.Max(peak, v)
+		}
+	}
+	labelWidth := len(fmt.Sprintf("%.0f", peak)) + 1
+	plotWidth := width - labelWidth
+	if plotWidth < 2 || height < 1 || points == 0 {
+		return nil
+	}
+	if peak == 0 {
+		peak = 1
+	}
+
+	dotsW, dotsH := plotWidth*2, height*4
+	cells := make([][]rune, height)
+	colors := make([][]lipgloss.Color, height)
+	for r := range cells {
+		cells[r] = make([]rune, plotWidth)
+		colors[r] = make([]lipgloss.Color, plotWidth)
+	}
+	set := func(x, y int, c lipgloss.Color) {
+		if x < 0 || x >= dotsW || y < 0 || y >= dotsH {
+			return
+		}
+		row, col := y/4, x/2
+		cells[row][col] |= brailleDots[y%4][x%2]
+		colors[row][col] = c
+	}
+	toXY := func(i int, v float64) (int, int) {
+		x := 0
+		if points > 1 {
+			x = int(math.Round(float64(i) / float64(points-1) * float64(dotsW-1)))
+		}
+		y := dotsH - 1 - int(math.Round(v/peak*float64(dotsH-1)))
+		return x, y
+	}
+	for _, s := range series {
+		for i := range s.Values {
+			x1, y1 := toXY(i, s.Values[i])
+			if i == 0 {
+				set(x1, y1, s.Color)
+				continue
+			}
+			// Connect to the previous point with a straight segment.
+			x0, y0 := toXY(i-1, s.Values[i-1])
+			steps := max(abs(x1-x0), abs(y1-y0), 1)
+			for k := 0; k <= steps; k++ {
+				x := x0 + (x1-x0)*k/steps
+				y := y0 + (y1-y0)*k/steps
+				set(x, y, s.Color)
+			}
+		}
+	}
+
+	lines := make([]string, height)
+	axis := lipgloss.NewStyle().Foreground(ColorGray)
+	for r := 0; r < height; r++ {
+		label := ""
+		switch r {
+		case 0:
+			label = fmt.Sprintf("%.0f", peak)
+		case height - 1:
+			label = "0"
+		}
+		var b strings.Builder
+		b.WriteString(axis.Render(PadLeft(label, labelWidth-1) + "┤"))
+		for c := 0; c < plotWidth; c++ {
+			if cells[r][c] == 0 {
+				b.WriteRune(' ')
+				continue
+			}
+			ch := string(rune(0x2800) + cells[r][c])
+			b.WriteString(lipgloss.NewStyle().Foreground(colors[r][c]).Render(ch))
+		}
+		lines[r] = b.String()
+	}
+	return lines
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
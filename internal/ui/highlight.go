@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Match is a byte range in the plain (ANSI-stripped) text of a line.
+type Match struct {
+	Start, End int
+}
+
+// Highlight overlays search matches on an already-styled line. Ranges refer
+// to the line's plain text and must be sorted and non-overlapping; the range
+// at index current uses SearchCurrentStyle, the rest SearchMatchStyle.
+//
+// Pre-styled text ends its runs with SGR resets, so the highlight is
+// re-applied after every SGR sequence inside a match, and the line's own
+// attributes are replayed when a match ends.
+func Highlight(line string, matches []Match, current int) string {
+	if len(matches) == 0 {
+		return line
+	}
+	matchSGR := sgrPrefix(SearchMatchStyle)
+	currentSGR := sgrPrefix(SearchCurrentStyle)
+	if matchSGR == "" && currentSGR == "" {
+		return line // no color support
+	}
+	open := func(m int) string {
+		if m == current {
+			return currentSGR
+		}
+		return matchSGR
+	}
+
+	var b strings.Builder
+	var active []string // SGR sequences in effect since the last reset
+	pos := 0            // offset into the plain text
+	next := 0           // next match to open
+	in := -1            // match currently open
+	closeMatch := func() {
+		b.WriteString("\x1b[0m")
+		b.WriteString(strings.Join(active, ""))
+		in = -1
+	}
+
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b {
+			n := escapeLen(line[i:])
+			seq := line[i : i+n]
+			b.WriteString(seq)
+			if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					active = active[:0]
+				} else {
+					active = append(active, seq)
+				}
+				if in >= 0 {
+					b.WriteString(open(in))
+				}
+			}
+			i += n
+			continue
+		}
+		if in >= 0 && pos >= matches[in].End {
+			closeMatch()
+		}
+		for in < 0 && next < len(matches) && matches[next].End <= pos {
+			next++ // empty or stale range
+		}
+		if in < 0 && next < len(matches) && pos >= matches[next].Start {
+			in = next
+			next++
+			b.WriteString(open(in))
+		}
+		b.WriteByte(line[i])
+		i++
+		pos++
+	}
+	if in >= 0 {
+		closeMatch()
+	}
+	return b.String()
+}
+
+// sgrPrefix returns the escape sequence a style emits before its text, or
+// "" when the terminal has no color support.
+func sgrPrefix(style lipgloss.Style) string {
+	s := style.Render("x")
+	return s[:strings.IndexByte(s, 'x')]
+}
+
+// escapeLen returns the length of the escape sequence at the start of s:
+// CSI sequences up to their final byte, OSC/DCS/APC strings up to BEL or ST,
+// and two bytes for anything else.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']', 'P', '_', '^', 'X':
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
+
+// Plain strips escape sequences from a line, giving the text that
+// Highlight's match offsets refer to.
+func Plain(line string) string {
+	if !strings.Contains(line, "\x1b") {
+		return line
+	}
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b {
+			i += escapeLen(line[i:])
+			continue
+		}
+		b.WriteByte(line[i])
+		i++
+	}
+	return b.String()
+}
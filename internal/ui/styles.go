@@ -122,6 +122,16 @@ var (
 
 	FilterInputStyle = lipgloss.NewStyle().
 				Foreground(ColorWhite)
+
+	// Search matches (see Highlight); the current match stands out.
+	SearchMatchStyle = lipgloss.NewStyle().
+				Background(ColorGray).
+				Foreground(ColorBg)
+
+	SearchCurrentStyle = lipgloss.NewStyle().
+				Bold(true).
+				Background(ColorYellow).
+				Foreground(ColorBg)
 )
 
 // PriorityStyle returns a style colored by priority level.
@@ -160,6 +170,27 @@ func StatusStyle(status string) lipgloss.Style {
 	}
 }
 
+// StatusColor returns the color used for a status, for charts that need a
+// bare color rather than a style.
+func StatusColor(status string) lipgloss.Color {
+	switch status {
+	case "open":
+		return ColorGreen
+	case "in_progress":
+		return ColorCyan
+	case "blocked":
+		return ColorRed
+	case "deferred":
+		return ColorMagenta
+	case "closed":
+		return ColorGray
+	case "pinned":
+		return ColorYellow
+	default:
+		return ColorDimGray
+	}
+}
+
 // TypeStyle returns a style colored by issue type.
 func TypeStyle(issueType string) lipgloss.Style {
 	switch issueType {
@@ -178,6 +209,33 @@ func TypeStyle(issueType string) lipgloss.Style {
 	}
 }
 
+// DueStyle returns the style for a due date: red when overdue, gray
+// otherwise.
+func DueStyle(overdue bool) lipgloss.Style {
+	if overdue {
+		return ErrorStyle
+	}
+	return lipgloss.NewStyle().Foreground(ColorGray)
+}
+
+// ChangeKindStyle returns a style colored by activity change kind.
+func ChangeKindStyle(kind string) lipgloss.Style {
+	switch kind {
+	case "created":
+		return lipgloss.NewStyle().Foreground(ColorGreen)
+	case "status":
+		return lipgloss.NewStyle().Foreground(ColorCyan)
+	case "priority":
+		return lipgloss.NewStyle().Foreground(ColorYellow)
+	case "comment":
+		return lipgloss.NewStyle().Foreground(ColorMagenta)
+	case "closed":
+		return lipgloss.NewStyle().Foreground(ColorGray)
+	default:
+		return lipgloss.NewStyle().Foreground(ColorWhite)
+	}
+}
+
 // StatusBadge returns a colored icon + status string for compact display in
 // dependency/dependent lists.
 func StatusBadge(status string) string {
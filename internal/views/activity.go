@@ -0,0 +1,371 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// maxActivityEntries caps the in-memory activity log for long sessions.
+const maxActivityEntries = 1000
+
+// activityKinds lists the filterable change kinds in key order (1-6).
+var activityKinds = []models.ChangeKind{
+	models.ChangeCreated,
+	models.ChangeStatus,
+	models.ChangePriority,
+	models.ChangeComment,
+	models.ChangeClosed,
+	models.ChangeUpdated,
+}
+
+// ActivityView is a session-long log of changes detected between data loads.
+type ActivityView struct {
+	entries    []models.IssueChange // newest first
+	filtered   []models.IssueChange
+	kindFilter map[models.ChangeKind]bool // empty = all kinds
+	cursor     int
+	offset     int
+	width      int
+	height     int
+
+	filterInput textinput.Model
+	filtering   bool
+	filterText  string
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewActivityView creates an empty activity log.
+func NewActivityView() *ActivityView {
+	ti := textinput.New()
+	ti.Placeholder = "filter..."
+	ti.CharLimit = 100
+	return &ActivityView{
+		kindFilter:  make(map[models.ChangeKind]bool),
+		filterInput: ti,
+	}
+}
+
+// Record prepends newly detected changes to the log.
+func (a *ActivityView) Record(changes []models.IssueChange) {
+	if len(changes) == 0 {
+		return
+	}
+	// Keep the log newest first, both within the batch and overall.
+	batch := make([]models.IssueChange, len(changes))
+	copy(batch, changes)
+	sort.SliceStable(batch, func(i, j int) bool {
+		return batch[i].At.After(batch[j].At)
+	})
+	a.entries = append(batch, a.entries...)
+	if len(a.entries) > maxActivityEntries {
+		a.entries = a.entries[:maxActivityEntries]
+	}
+	a.applyFilter()
+}
+
+// Len returns the total number of recorded entries.
+func (a *ActivityView) Len() int {
+	return len(a.entries)
+}
+
+// SetSize sets terminal dimensions.
+func (a *ActivityView) SetSize(w, h int) {
+	a.width = w
+	a.height = h
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (a *ActivityView) SetStatusMsg(msg string) {
+	a.statusMsg = msg
+}
+
+// IsFiltering returns whether the filter input is active.
+func (a *ActivityView) IsFiltering() bool {
+	return a.filtering
+}
+
+// SelectedEntry returns the entry under the cursor, or nil.
+func (a *ActivityView) SelectedEntry() *models.IssueChange {
+	if len(a.filtered) == 0 || a.cursor >= len(a.filtered) {
+		return nil
+	}
+	return &a.filtered[a.cursor]
+}
+
+// Update handles key messages for the activity view.
+func (a *ActivityView) Update(msg tea.Msg) tea.Cmd {
+	if a.filtering {
+		return a.updateFiltering(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if a.cursor < len(a.filtered)-1 {
+				a.cursor++
+				a.ensureVisible()
+			}
+		case "k", "up":
+			if a.cursor > 0 {
+				a.cursor--
+				a.ensureVisible()
+			}
+		case "g", "home":
+			a.cursor = 0
+			a.offset = 0
+		case "G", "end":
+			a.cursor = max(0, len(a.filtered)-1)
+			a.ensureVisible()
+		case "ctrl+d":
+			a.cursor = min(a.cursor+a.visibleRows()/2, max(0, len(a.filtered)-1))
+			a.ensureVisible()
+		case "ctrl+u":
+			a.cursor = max(a.cursor-a.visibleRows()/2, 0)
+			a.ensureVisible()
+		case "1", "2", "3", "4", "5", "6":
+			kind := activityKinds[msg.String()[0]-'1']
+			a.kindFilter[kind] = !a.kindFilter[kind]
+			if !a.kindFilter[kind] {
+				delete(a.kindFilter, kind)
+			}
+			a.applyFilter()
+		case "0":
+			a.kindFilter = make(map[models.ChangeKind]bool)
+			a.applyFilter()
+		case "/":
+			a.filtering = true
+			a.filterInput.Focus()
+			return textinput.Blink
+		case "enter":
+			if e := a.SelectedEntry(); e != nil {
+				id := e.IssueID
+				return func() tea.Msg {
+					return NavigateToIssueMsg{ID: id}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *ActivityView) updateFiltering(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			a.filterText = a.filterInput.Value()
+			a.filtering = false
+			a.filterInput.Blur()
+			a.applyFilter()
+			return nil
+		case "esc":
+			a.filtering = false
+			a.filterInput.Blur()
+			a.filterInput.SetValue(a.filterText)
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	a.filterInput, cmd = a.filterInput.Update(msg)
+	a.filterText = a.filterInput.Value()
+	a.applyFilter()
+	return cmd
+}
+
+func (a *ActivityView) applyFilter() {
+	needle := strings.ToLower(a.filterText)
+	var filtered []models.IssueChange
+	for _, e := range a.entries {
+		if len(a.kindFilter) > 0 && !a.kindFilter[e.Kind] {
+			continue
+		}
+		if needle != "" &&
+			!strings.Contains(strings.ToLower(e.IssueID), needle) &&
+			!strings.Contains(strings.ToLower(e.Title), needle) &&
+			!strings.Contains(strings.ToLower(e.Summary()), needle) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	a.filtered = filtered
+	if a.cursor >= len(a.filtered) {
+		a.cursor = max(0, len(a.filtered)-1)
+	}
+	a.ensureVisible()
+}
+
+func (a *ActivityView) visibleRows() int {
+	tblHdr := ui.TableHeaderStyle.Width(a.width).Render("")
+	chrome := []string{a.renderHeader(), tblHdr, a.renderStatusBar()}
+	if a.filtering {
+		chrome = append(chrome, " ")
+	}
+	return ui.ContentHeight(a.height, chrome...)
+}
+
+func (a *ActivityView) ensureVisible() {
+	vis := a.visibleRows()
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	}
+	if a.cursor >= a.offset+vis {
+		a.offset = a.cursor - vis + 1
+	}
+}
+
+// View renders the activity view.
+func (a *ActivityView) View() string {
+	var b strings.Builder
+	b.WriteString(a.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(a.renderTable())
+	if a.filtering {
+		b.WriteString("\n")
+		b.WriteString(ui.FilterPromptStyle.Render("/") + " " + a.filterInput.View())
+	}
+	b.WriteString("\n")
+	b.WriteString(a.renderStatusBar())
+	return b.String()
+}
+
+func (a *ActivityView) renderHeader() string {
+	left := ui.LogoStyle.Render("activity") + "  " +
+		fmt.Sprintf("%d of %d changes this session", len(a.filtered), len(a.entries))
+
+	right := ""
+	if len(a.kindFilter) > 0 {
+		var kinds []string
+		for _, k := range activityKinds {
+			if a.kindFilter[k] {
+				kinds = append(kinds, k.String())
+			}
+		}
+		right = ui.KeyStyle.Render("kind:") + " " + ui.KeyDescStyle.Render(strings.Join(kinds, ","))
+	}
+	if a.filterText != "" {
+		right += "  " + ui.KeyStyle.Render("search:") + " " + ui.KeyDescStyle.Render(a.filterText)
+	}
+
+	gap := max(0, a.width-lipgloss.Width(left)-lipgloss.Width(right)-2)
+	return ui.HeaderStyle.Width(a.width).Render(left + strings.Repeat(" ", gap) + right)
+}
+
+func (a *ActivityView) renderTable() string {
+	if len(a.filtered) == 0 {
+		msg := "No changes detected yet. Changes appear here as the database is updated."
+		if len(a.entries) > 0 {
+			msg = "No changes match the current filter (press 0 to clear)."
+		}
+		emptyHeight := max(1, a.height-6)
+		return strings.Repeat("\n", emptyHeight/2) + lipgloss.NewStyle().
+			Width(a.width).
+			Align(lipgloss.Center).
+			Foreground(ui.ColorGray).
+			Render(msg)
+	}
+
+	tbl := ui.NewTable(
+		&ui.Column{Header: "TIME", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 8},
+		&ui.Column{Header: "KIND", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 8},
+		&ui.Column{Header: "ID", Size: ui.SizeFit, Align: ui.AlignLeft, Min: 4, Max: 20},
+		&ui.Column{Header: "TITLE", Size: ui.SizeFlex, Align: ui.AlignLeft, Min: 10, Max: 50},
+		&ui.Column{Header: "CHANGE", Size: ui.SizeFlex, Align: ui.AlignLeft, Min: 10},
+	)
+	dataWidths := make([]int, 5)
+	for _, e := range a.filtered {
+		if n := ui.StringWidth(e.IssueID); n > dataWidths[2] {
+			dataWidths[2] = n
+		}
+	}
+	cursorWidth := 2
+	tbl.Resolve(a.width-cursorWidth, dataWidths)
+
+	headers := make([]string, len(tbl.Columns))
+	for i, col := range tbl.Columns {
+		headers[i] = col.Header
+	}
+	rows := []string{ui.TableHeaderStyle.Width(a.width).Render("  " + tbl.RenderRow(headers, nil))}
+
+	vis := a.visibleRows()
+	end := min(a.offset+vis, len(a.filtered))
+	for i := a.offset; i < end; i++ {
+		e := a.filtered[i]
+		selected := i == a.cursor
+
+		cursor := "  "
+		if selected {
+			cursor = "> "
+		}
+		cells := []string{
+			formatActivityTime(e.At),
+			e.Kind.String(),
+			e.IssueID,
+			e.Title,
+			e.Summary(),
+		}
+		styleFn := func(col int, padded string) string {
+			switch col {
+			case 0:
+				return lipgloss.NewStyle().Foreground(ui.ColorGray).Render(padded)
+			case 1:
+				return ui.ChangeKindStyle(e.Kind.String()).Render(padded)
+			default:
+				return padded
+			}
+		}
+		row := cursor + tbl.RenderRow(cells, styleFn)
+		if selected {
+			row = ui.SelectedRowStyle.Width(a.width).Render(row)
+		}
+		rows = append(rows, row)
+	}
+	for len(rows)-1 < vis {
+		rows = append(rows, strings.Repeat(" ", a.width))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (a *ActivityView) renderStatusBar() string {
+	if a.statusMsg != "" {
+		return ui.StatusBarStyle.Width(a.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(a.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"enter", "view"},
+		{"/", "filter"},
+		{"1-6", "kind"},
+		{"0", "all"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(a.width).Render(strings.Join(parts, "  "))
+}
+
+// formatActivityTime shows a clock time for today's changes and a date
+// otherwise.
+func formatActivityTime(t time.Time) string {
+	t = t.Local()
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("Jan 02")
+}
@@ -0,0 +1,437 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/agenda"
+	"github.com/poiley/beady/internal/analytics"
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// agendaMode selects the agenda view's layout.
+type agendaMode int
+
+const (
+	agendaList  agendaMode = iota // sections: overdue, today, this week, ...
+	agendaMonth                   // month grid with per-day counts
+	agendaDay                     // issues on the selected day
+)
+
+// AgendaView shows due dates and deferral wake dates as an agenda and a
+// month calendar.
+type AgendaView struct {
+	entries []agenda.Entry
+	byDay   map[time.Time][]agenda.Entry
+	mode    agendaMode
+
+	// Agenda and day list content.
+	lines    []string
+	navItems []navItem
+	cursor   int
+	scroll   int
+
+	// Calendar selection: the selected day (local midnight).
+	day time.Time
+
+	width  int
+	height int
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewAgendaView creates an agenda view with today selected.
+func NewAgendaView() *AgendaView {
+	return &AgendaView{day: analytics.Day(time.Now())}
+}
+
+// SetData rebuilds the agenda from the issue list, keeping the cursor on
+// the same issue when possible.
+func (a *AgendaView) SetData(issues []models.Issue) {
+	selected := a.SelectedID()
+	a.entries = agenda.Entries(issues, time.Now())
+	a.byDay = agenda.ByDay(a.entries)
+	a.buildContent()
+	a.selectID(selected)
+}
+
+// SetSize sets terminal dimensions.
+func (a *AgendaView) SetSize(w, h int) {
+	a.width = w
+	a.height = h
+	a.buildContent()
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (a *AgendaView) SetStatusMsg(msg string) {
+	a.statusMsg = msg
+}
+
+// Back leaves the day list for the calendar. It returns false when there
+// is nothing to go back to within the view.
+func (a *AgendaView) Back() bool {
+	if a.mode != agendaDay {
+		return false
+	}
+	a.mode = agendaMonth
+	a.buildContent()
+	return true
+}
+
+// SelectedID returns the issue under the cursor, or "".
+func (a *AgendaView) SelectedID() string {
+	if a.mode == agendaMonth || a.cursor < 0 || a.cursor >= len(a.navItems) {
+		return ""
+	}
+	return a.navItems[a.cursor].issueID
+}
+
+func (a *AgendaView) selectID(id string) {
+	a.cursor = min(a.cursor, max(0, len(a.navItems)-1))
+	for i, n := range a.navItems {
+		if n.issueID == id {
+			a.cursor = i
+			break
+		}
+	}
+	a.scrollToCursor()
+}
+
+// Update handles key messages.
+func (a *AgendaView) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	if keyMsg.String() == "tab" {
+		if a.mode == agendaList {
+			a.mode = agendaMonth
+		} else {
+			a.mode = agendaList
+		}
+		a.cursor, a.scroll = 0, 0
+		a.buildContent()
+		return nil
+	}
+	if a.mode == agendaMonth {
+		a.updateMonth(keyMsg)
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "j", "down":
+		if a.cursor < len(a.navItems)-1 {
+			a.cursor++
+			a.scrollToCursor()
+		}
+	case "k", "up":
+		if a.cursor > 0 {
+			a.cursor--
+			a.scrollToCursor()
+		}
+	case "g", "home":
+		a.cursor = 0
+		a.scroll = 0
+	case "G", "end":
+		a.cursor = max(0, len(a.navItems)-1)
+		a.scrollToCursor()
+	case "enter":
+		if id := a.SelectedID(); id != "" {
+			return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+		}
+	}
+	return nil
+}
+
+func (a *AgendaView) updateMonth(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "h", "left":
+		a.day = a.day.AddDate(0, 0, -1)
+	case "l", "right":
+		a.day = a.day.AddDate(0, 0, 1)
+	case "k", "up":
+		a.day = a.day.AddDate(0, 0, -7)
+	case "j", "down":
+		a.day = a.day.AddDate(0, 0, 7)
+	case "[":
+		a.day = a.day.AddDate(0, -1, 0)
+	case "]":
+		a.day = a.day.AddDate(0, 1, 0)
+	case "t":
+		a.day = analytics.Day(time.Now())
+	case "enter":
+		a.mode = agendaDay
+		a.cursor, a.scroll = 0, 0
+		a.buildContent()
+	}
+}
+
+func (a *AgendaView) scrollToCursor() {
+	if a.cursor < 0 || a.cursor >= len(a.navItems) {
+		return
+	}
+	line := a.navItems[a.cursor].lineIndex
+	vis := a.visibleLines()
+	if line < a.scroll {
+		a.scroll = line
+	} else if line >= a.scroll+vis {
+		a.scroll = line - vis + 1
+	}
+}
+
+func (a *AgendaView) visibleLines() int {
+	return ui.ContentHeight(a.height, a.renderHeader(), a.renderStatusBar())
+}
+
+// buildContent renders the agenda sections or the selected day's issues.
+func (a *AgendaView) buildContent() {
+	now := time.Now()
+	contentWidth := max(20, a.width-4)
+	gray := lipgloss.NewStyle().Foreground(ui.ColorGray)
+	var lines []string
+	var navItems []navItem
+
+	section := func(title string) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, ui.SectionHeaderStyle.Render(title), ui.TableHeaderStyle.Width(contentWidth).Render(""))
+	}
+	entry := func(e agenda.Entry) {
+		navItems = append(navItems, navItem{lineIndex: len(lines), issueID: e.Issue.ID})
+		lines = append(lines, a.entryLine(e, now, contentWidth))
+	}
+
+	switch a.mode {
+	case agendaList:
+		for _, s := range agenda.Group(a.entries, now) {
+			section(fmt.Sprintf("%s (%d)", s.Bucket, len(s.Entries)))
+			for _, e := range s.Entries {
+				entry(e)
+			}
+		}
+		if len(a.entries) == 0 {
+			lines = append(lines, gray.Render("No open issues have a due date or a deferral."))
+		}
+	case agendaDay:
+		entries := a.byDay[a.day]
+		section(fmt.Sprintf("%s (%d)", strings.ToUpper(a.day.Format("Monday, January 2")), len(entries)))
+		for _, e := range entries {
+			entry(e)
+		}
+		if len(entries) == 0 {
+			lines = append(lines, gray.Render("Nothing due or waking up on this day."))
+		}
+	}
+
+	a.lines = lines
+	a.navItems = navItems
+	if a.cursor >= len(a.navItems) {
+		a.cursor = max(0, len(a.navItems)-1)
+	}
+}
+
+// entryLine renders one agenda entry: date, relative time, kind, issue.
+func (a *AgendaView) entryLine(e agenda.Entry, now time.Time, width int) string {
+	rel := models.RelativeAge(e.At)
+	if e.At.After(now) {
+		rel = "in " + rel
+	} else if rel != "now" {
+		rel += " ago"
+	}
+	when := ui.DueStyle(e.Overdue(now)).Render(ui.PadStr(e.At.Local().Format("Mon Jan 02"), 12) + ui.PadStr(rel, 9))
+	kind := ui.PadStr(e.Kind.String(), 6)
+	if e.Kind == agenda.KindWakes {
+		kind = ui.StatusStyle("deferred").Render(kind)
+	}
+	prefix := fmt.Sprintf("%s %s %s  %s  ",
+		when,
+		kind,
+		ui.PriorityStyle(e.Issue.Priority).Render(e.Issue.PriorityString()),
+		e.Issue.ID,
+	)
+	title := ui.Truncate(e.Issue.Title, max(10, width-lipgloss.Width(prefix)))
+	return prefix + title
+}
+
+// View renders the agenda view.
+func (a *AgendaView) View() string {
+	var body string
+	if a.mode == agendaMonth {
+		body = a.renderMonth()
+	} else {
+		body = a.renderLines()
+	}
+
+	var b strings.Builder
+	b.WriteString(a.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(body)
+	b.WriteString("\n")
+	b.WriteString(a.renderStatusBar())
+	return b.String()
+}
+
+func (a *AgendaView) renderLines() string {
+	vis := a.visibleLines()
+	highlight := -1
+	if a.cursor >= 0 && a.cursor < len(a.navItems) {
+		highlight = a.navItems[a.cursor].lineIndex
+	}
+	end := min(a.scroll+vis, len(a.lines))
+	visible := make([]string, 0, vis)
+	for i := a.scroll; i < end; i++ {
+		line := a.lines[i]
+		if i == highlight {
+			line = ui.SelectedRowStyle.Width(a.width - 4).Render(line)
+		}
+		visible = append(visible, "  "+line)
+	}
+	for len(visible) < vis {
+		visible = append(visible, "")
+	}
+	return strings.Join(visible, "\n")
+}
+
+// renderMonth draws the month containing the selected day as a Monday-first
+// grid. Each day shows how many issues are due (red when any is overdue)
+// and how many wake up.
+func (a *AgendaView) renderMonth() string {
+	now := time.Now()
+	today := analytics.Day(now)
+	first := time.Date(a.day.Year(), a.day.Month(), 1, 0, 0, 0, 0, time.Local)
+	start := agenda.WeekStart(first)
+	cellW := min(14, max(6, (a.width-4)/7))
+	gray := lipgloss.NewStyle().Foreground(ui.ColorGray)
+
+	lines := []string{
+		ui.SectionHeaderStyle.Render(strings.ToUpper(first.Format("January 2006"))),
+		"",
+	}
+	var hdr strings.Builder
+	for _, d := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		hdr.WriteString(ui.PadStr(d, cellW))
+	}
+	lines = append(lines, ui.TableHeaderStyle.Render(hdr.String()))
+
+	for week := start; week.Before(first.AddDate(0, 1, 0)); week = week.AddDate(0, 0, 7) {
+		var row strings.Builder
+		for i := range 7 {
+			day := week.AddDate(0, 0, i)
+			row.WriteString(a.renderDayCell(day, first.Month(), today, now, cellW))
+		}
+		lines = append(lines, row.String(), "")
+	}
+
+	// Preview of the selected day below the grid.
+	entries := a.byDay[a.day]
+	lines = append(lines, ui.SectionHeaderStyle.Render(a.day.Format("Monday, January 2")))
+	if len(entries) == 0 {
+		lines = append(lines, gray.Render("Nothing due or waking up."))
+	}
+	for _, e := range entries {
+		lines = append(lines, a.entryLine(e, now, max(20, a.width-4)))
+	}
+
+	vis := a.visibleLines()
+	if len(lines) > vis {
+		lines = lines[:vis]
+	}
+	for len(lines) < vis {
+		lines = append(lines, "")
+	}
+	for i := range lines {
+		lines[i] = "  " + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (a *AgendaView) renderDayCell(day time.Time, month time.Month, today, now time.Time, width int) string {
+	due, wakes, overdue := 0, 0, false
+	for _, e := range a.byDay[day] {
+		if e.Kind == agenda.KindWakes {
+			wakes++
+			continue
+		}
+		due++
+		overdue = overdue || e.Overdue(now)
+	}
+
+	label := fmt.Sprintf("%2d", day.Day())
+	var counts string
+	if due > 0 {
+		counts += " " + ui.DueStyle(overdue).Render(fmt.Sprintf("●%d", due))
+	}
+	if wakes > 0 {
+		counts += " " + ui.StatusStyle("deferred").Render(fmt.Sprintf("◌%d", wakes))
+	}
+
+	style := lipgloss.NewStyle()
+	switch {
+	case day.Equal(a.day):
+		style = ui.SelectedRowStyle
+	case day.Month() != month:
+		style = style.Foreground(ui.ColorDimGray)
+	case day.Equal(today):
+		style = style.Bold(true).Foreground(ui.ColorCyan)
+	}
+	cell := style.Render(label) + counts
+	return cell + strings.Repeat(" ", max(0, width-lipgloss.Width(cell)))
+}
+
+func (a *AgendaView) renderHeader() string {
+	overdue, due := 0, 0
+	now := time.Now()
+	for _, e := range a.entries {
+		if e.Kind == agenda.KindDue {
+			due++
+			if e.Overdue(now) {
+				overdue++
+			}
+		}
+	}
+	left := ui.LogoStyle.Render("agenda") + "  " + fmt.Sprintf("%d with due dates", due)
+	if overdue > 0 {
+		left += "  " + ui.ErrorStyle.Render(fmt.Sprintf("%d overdue", overdue))
+	}
+	return ui.HeaderStyle.Width(a.width).Render(left)
+}
+
+func (a *AgendaView) renderStatusBar() string {
+	if a.statusMsg != "" {
+		return ui.StatusBarStyle.Width(a.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(a.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"tab", "agenda/month"},
+		{"j/k", "move"},
+		{"enter", "view"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	if a.mode == agendaMonth {
+		keys = []struct{ key, desc string }{
+			{"esc", "back"},
+			{"tab", "agenda/month"},
+			{"hjkl", "day"},
+			{"[/]", "month"},
+			{"t", "today"},
+			{"enter", "open day"},
+			{"?", "help"},
+			{"q", "quit"},
+		}
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(a.width).Render(strings.Join(parts, "  "))
+}
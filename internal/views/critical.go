@@ -0,0 +1,291 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/graph"
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// CriticalPathView shows the remaining-work analysis of an epic: the
+// critical path, the top blockers and everything still open.
+type CriticalPathView struct {
+	analysis     *graph.EpicAnalysis
+	avgLeadHours float64
+	width        int
+	height       int
+	scroll       int
+
+	lines    []string  // pre-rendered content lines
+	navItems []navItem // issue links, in line order
+	cursor   int       // index into navItems
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewCriticalPathView creates a view for an analyzed epic.
+func NewCriticalPathView(a *graph.EpicAnalysis, avgLeadHours float64) *CriticalPathView {
+	v := &CriticalPathView{analysis: a, avgLeadHours: avgLeadHours}
+	v.buildContent()
+	return v
+}
+
+// SetAnalysis replaces the analysis (after a data refresh), keeping the
+// cursor on the same issue when possible.
+func (v *CriticalPathView) SetAnalysis(a *graph.EpicAnalysis, avgLeadHours float64) {
+	selected := v.SelectedID()
+	v.analysis = a
+	v.avgLeadHours = avgLeadHours
+	v.buildContent()
+	v.cursor = 0
+	for i, n := range v.navItems {
+		if n.issueID == selected {
+			v.cursor = i
+			break
+		}
+	}
+}
+
+// EpicID returns the analyzed epic's ID.
+func (v *CriticalPathView) EpicID() string {
+	return v.analysis.Epic.ID
+}
+
+// SetSize sets terminal dimensions.
+func (v *CriticalPathView) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+	v.buildContent()
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (v *CriticalPathView) SetStatusMsg(msg string) {
+	v.statusMsg = msg
+}
+
+// SelectedID returns the issue under the cursor, or "".
+func (v *CriticalPathView) SelectedID() string {
+	if v.cursor < 0 || v.cursor >= len(v.navItems) {
+		return ""
+	}
+	return v.navItems[v.cursor].issueID
+}
+
+// Update handles key messages.
+func (v *CriticalPathView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down", "tab":
+			if v.cursor < len(v.navItems)-1 {
+				v.cursor++
+				v.scrollToCursor()
+			}
+		case "k", "up", "shift+tab":
+			if v.cursor > 0 {
+				v.cursor--
+				v.scrollToCursor()
+			}
+		case "g", "home":
+			v.cursor = 0
+			v.scroll = 0
+		case "G", "end":
+			v.cursor = max(0, len(v.navItems)-1)
+			v.scroll = max(0, len(v.lines)-v.visibleLines())
+		case "enter":
+			if id := v.SelectedID(); id != "" {
+				return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+			}
+		}
+	}
+	return nil
+}
+
+func (v *CriticalPathView) scrollToCursor() {
+	if v.cursor < 0 || v.cursor >= len(v.navItems) {
+		return
+	}
+	line := v.navItems[v.cursor].lineIndex
+	vis := v.visibleLines()
+	if line < v.scroll {
+		v.scroll = line
+	} else if line >= v.scroll+vis {
+		v.scroll = line - vis + 1
+	}
+}
+
+func (v *CriticalPathView) visibleLines() int {
+	return ui.ContentHeight(v.height, v.renderHeader(), v.renderStatusBar())
+}
+
+func (v *CriticalPathView) buildContent() {
+	a := v.analysis
+	contentWidth := max(20, v.width-4)
+	var lines []string
+	var navItems []navItem
+	add := func(s string) { lines = append(lines, s) }
+	gray := lipgloss.NewStyle().Foreground(ui.ColorGray)
+
+	add(lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite).Render(a.Epic.Title))
+	add("")
+
+	field := func(label, value string) {
+		add(ui.FieldLabelStyle.Render(label) + ui.FieldValueStyle.Render(value))
+	}
+	field("Progress", fmt.Sprintf("%d/%d closed, %d unfinished in scope", a.Closed, a.Total, len(a.Remaining)))
+	if len(a.Path) > 0 {
+		field("Critical Path", fmt.Sprintf("%d steps, %s of work", a.WorkSteps(), formatMinutes(a.PathMinutes)))
+		if t, ok := a.Projected(v.avgLeadHours, time.Now()); ok {
+			field("Projected", fmt.Sprintf("%s  (%d steps × %.1fh avg lead time)",
+				t.Format("2006-01-02"), a.WorkSteps(), v.avgLeadHours))
+		} else {
+			field("Projected", gray.Render("no lead time data yet"))
+		}
+	}
+
+	issueLine := func(prefix string, issue models.Issue, suffix string) string {
+		return fmt.Sprintf("%s%s  %s  %s  %s%s",
+			prefix,
+			issue.ID,
+			ui.StatusBadge(issue.Status),
+			ui.PriorityStyle(issue.Priority).Render(issue.PriorityString()),
+			issue.Title,
+			suffix,
+		)
+	}
+	section := func(title string) {
+		add("")
+		add(ui.SectionHeaderStyle.Render(title))
+		add(ui.TableHeaderStyle.Width(contentWidth).Render(""))
+	}
+
+	if len(a.Remaining) == 0 {
+		add("")
+		add(gray.Render("Nothing left to do in this epic."))
+	}
+
+	if len(a.Path) > 0 {
+		section("CRITICAL PATH")
+		for i, step := range a.Path {
+			prefix := "  ├─ "
+			if i == len(a.Path)-1 {
+				prefix = "  └─ "
+			}
+			est := formatMinutes(step.Minutes)
+			switch {
+			case step.Container:
+				est = "children"
+			case !step.Estimated:
+				est = "~" + est
+			}
+			navItems = append(navItems, navItem{lineIndex: len(lines), issueID: step.Issue.ID})
+			add(issueLine(prefix, step.Issue, gray.Render("  ("+est+")")))
+		}
+	}
+
+	if len(a.Blockers) > 0 {
+		section("TOP BLOCKERS")
+		for _, b := range a.Blockers {
+			count := lipgloss.NewStyle().Foreground(ui.ColorRed).Render(fmt.Sprintf("%3d", b.Blocks))
+			navItems = append(navItems, navItem{lineIndex: len(lines), issueID: b.Issue.ID})
+			add(issueLine("  "+count+"  ", b.Issue, ""))
+		}
+	}
+
+	if len(a.Remaining) > 0 {
+		section(fmt.Sprintf("REMAINING (%d)", len(a.Remaining)))
+		for _, issue := range a.Remaining {
+			marker := "   "
+			if a.OnPath(issue.ID) {
+				marker = lipgloss.NewStyle().Foreground(ui.ColorYellow).Render(" ★ ")
+			}
+			navItems = append(navItems, navItem{lineIndex: len(lines), issueID: issue.ID})
+			add(issueLine(marker, issue, ""))
+		}
+	}
+
+	v.lines = lines
+	v.navItems = navItems
+	if v.cursor >= len(v.navItems) {
+		v.cursor = max(0, len(v.navItems)-1)
+	}
+}
+
+// View renders the critical path view.
+func (v *CriticalPathView) View() string {
+	vis := v.visibleLines()
+	maxScroll := max(0, len(v.lines)-vis)
+	if v.scroll > maxScroll {
+		v.scroll = maxScroll
+	}
+
+	highlight := -1
+	if v.cursor >= 0 && v.cursor < len(v.navItems) {
+		highlight = v.navItems[v.cursor].lineIndex
+	}
+	end := min(v.scroll+vis, len(v.lines))
+	visible := make([]string, 0, vis)
+	for i := v.scroll; i < end; i++ {
+		line := v.lines[i]
+		if i == highlight {
+			line = ui.SelectedRowStyle.Width(v.width - 4).Render(line)
+		}
+		visible = append(visible, "  "+line)
+	}
+	for len(visible) < vis {
+		visible = append(visible, "")
+	}
+
+	var b strings.Builder
+	b.WriteString(v.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(strings.Join(visible, "\n"))
+	b.WriteString("\n")
+	b.WriteString(v.renderStatusBar())
+	return b.String()
+}
+
+func (v *CriticalPathView) renderHeader() string {
+	epic := v.analysis.Epic
+	left := fmt.Sprintf("%s  %s  %s",
+		ui.LogoStyle.Render("critical path"),
+		ui.LogoStyle.Render(epic.ID),
+		ui.TypeStyle(epic.IssueType).Render(epic.IssueType),
+	)
+	return ui.HeaderStyle.Width(v.width).Render(left)
+}
+
+func (v *CriticalPathView) renderStatusBar() string {
+	if v.statusMsg != "" {
+		return ui.StatusBarStyle.Width(v.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(v.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"j/k", "move"},
+		{"enter", "view"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(v.width).Render(strings.Join(parts, "  "))
+}
+
+// formatMinutes formats a minute count like Issue.EstimateString does.
+func formatMinutes(m int) string {
+	if m <= 0 {
+		return "0m"
+	}
+	return (&models.Issue{EstimatedMinutes: m}).EstimateString()
+}
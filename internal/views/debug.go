@@ -0,0 +1,93 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/ui"
+)
+
+// DebugRow is one labelled value in the debug overlay.
+type DebugRow struct {
+	Label string
+	Value string
+}
+
+// DebugSection is a titled group of rows in the debug overlay.
+type DebugSection struct {
+	Title string
+	Rows  []DebugRow
+}
+
+// DebugView renders the diagnostics overlay: refresh and watcher
+// internals and recent bd commands, for working out why the data is or
+// isn't updating.
+type DebugView struct {
+	title    string
+	sections []DebugSection
+	width    int
+	height   int
+}
+
+// NewDebugView creates an empty overlay with the given title. Besides
+// diagnostics, it serves for any read-only list of labelled values.
+func NewDebugView(title string) *DebugView {
+	return &DebugView{title: title}
+}
+
+// SetSize sets terminal dimensions.
+func (d *DebugView) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SetSections replaces the overlay's contents.
+func (d *DebugView) SetSections(sections []DebugSection) {
+	d.sections = sections
+}
+
+// View renders the debug overlay.
+func (d *DebugView) View() string {
+	var b strings.Builder
+	b.WriteString(ui.HelpTitleStyle.Render(d.title))
+	b.WriteString("\n\n")
+
+	boxWidth := min(100, d.width-4)
+
+	labelWidth := 0
+	for _, s := range d.sections {
+		for _, r := range s.Rows {
+			labelWidth = max(labelWidth, ui.StringWidth(r.Label))
+		}
+	}
+	for _, s := range d.sections {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(ui.ColorCyan).Render(s.Title))
+		b.WriteString("\n")
+		for _, r := range s.Rows {
+			value := ui.Truncate(r.Value, max(10, boxWidth-6-labelWidth-1))
+			b.WriteString(ui.HelpKeyStyle.Width(labelWidth).Render(r.Label) + " " + ui.HelpDescStyle.Render(value))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(ui.ColorGray).Render("press any key to close"))
+
+	content := b.String()
+	boxHeight := min(strings.Count(content, "\n")+4, d.height-4)
+
+	box := lipgloss.NewStyle().
+		Width(boxWidth).
+		Height(boxHeight).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorBlue).
+		Background(ui.ColorBg).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width, d.height,
+		lipgloss.Center, lipgloss.Center,
+		box,
+	)
+}
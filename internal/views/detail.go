@@ -2,12 +2,15 @@ package views
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
-	"time"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/poiley/beady/internal/markdown"
 	"github.com/poiley/beady/internal/models"
 	"github.com/poiley/beady/internal/ui"
 )
@@ -17,6 +20,17 @@ type NavigateToIssueMsg struct {
 	ID string
 }
 
+// ToggleChecklistMsg asks the app to save an acceptance-criteria checkbox
+// toggle: the item on source line Line, with text Item, is to end up
+// checked if Done. The app applies it to the criteria as currently saved,
+// so it doesn't carry the full text.
+type ToggleChecklistMsg struct {
+	ID   string
+	Line int
+	Item string
+	Done bool
+}
+
 // sectionKind identifies a collapsible section in the detail view.
 type sectionKind int
 
@@ -31,10 +45,12 @@ const (
 	sectionCount // sentinel — total number of section kinds
 )
 
-// navItem maps a rendered line to a navigable issue link (for drill-down).
+// navItem maps a rendered line to a navigable issue link (for drill-down)
+// or an acceptance-criteria checkbox.
 type navItem struct {
-	lineIndex int    // index into d.lines
-	issueID   string // issue to navigate to on enter
+	lineIndex int                   // index into d.lines
+	issueID   string                // issue to navigate to on enter
+	task      *models.ChecklistItem // checkbox to toggle, nil for links
 }
 
 // DetailView shows full details for a single issue.
@@ -46,7 +62,7 @@ type DetailView struct {
 	lines     []string // pre-rendered content lines
 	statusMsg string   // temporary status bar message
 
-	// Navigation within parent/deps/dependents (issue links only).
+	// Navigation within parent/checklist/deps/dependents.
 	navItems  []navItem // navigable lines
 	navCursor int       // index into navItems, -1 = none selected
 
@@ -62,11 +78,53 @@ type DetailView struct {
 
 	// Collapsible sections.
 	collapsed [sectionCount]bool
+
+	// Show long-text fields as raw source instead of rendered markdown.
+	rawMarkdown bool
+
+	// Preview mode (split pane): no status bar, keys stay with the list.
+	preview bool
+
+	// In-view search. matches are in line order; matchLines maps a line
+	// index to its entries in matches.
+	searching    bool
+	searchInput  textinput.Model
+	searchQuery  string
+	searchRe     *regexp.Regexp
+	searchOrigin int // scroll position when the search prompt opened
+	matches      []searchMatch
+	matchLines   map[int][]int
+	matchCursor  int
+
+	// The user's personal star on this issue and its private note, edited
+	// in a prompt like the search one.
+	starred     bool
+	starNote    string
+	editingNote bool
+	noteInput   textinput.Model
+}
+
+// StarNoteMsg asks the app to save the private note on a starred issue.
+type StarNoteMsg struct {
+	ID   string
+	Note string
+}
+
+// searchMatch is one search hit in the rendered content.
+type searchMatch struct {
+	line  int
+	match ui.Match
 }
 
 // NewDetailView creates a detail view for an issue.
 func NewDetailView(issue *models.Issue) *DetailView {
-	d := &DetailView{issue: issue, navCursor: -1}
+	ti := textinput.New()
+	ti.Placeholder = "search..."
+	ti.CharLimit = 100
+	ni := textinput.New()
+	ni.Placeholder = "private note..."
+	ni.CharLimit = 200
+	d := &DetailView{issue: issue, navCursor: -1, searchInput: ti, noteInput: ni}
 	d.buildContent()
 	return d
 }
@@ -108,6 +166,56 @@ func (d *DetailView) UpdateIssue(issue *models.Issue) {
 	}
 }
 
+// SetRawMarkdown switches long-text fields between rendered markdown and
+// raw source.
+func (d *DetailView) SetRawMarkdown(raw bool) {
+	if d.rawMarkdown == raw {
+		return
+	}
+	d.rawMarkdown = raw
+	d.buildContent()
+	d.scroll = min(d.scroll, max(0, len(d.lines)-d.visibleLines()))
+}
+
+// SetPreview switches the view into split-pane preview mode, which drops
+// the status bar.
+func (d *DetailView) SetPreview(preview bool) {
+	d.preview = preview
+}
+
+// ScrollBy scrolls the content by delta lines, clamped to the content.
+func (d *DetailView) ScrollBy(delta int) {
+	d.scroll = max(0, min(d.scroll+delta, len(d.lines)-d.visibleLines()))
+}
+
+// SetStar sets whether the issue is starred and its private note, both
+// shown in the header.
+func (d *DetailView) SetStar(starred bool, note string) {
+	d.starred = starred
+	d.starNote = note
+}
+
+// IsEditingNote returns whether the star note prompt is active.
+func (d *DetailView) IsEditingNote() bool {
+	return d.editingNote
+}
+
+// IsSearching returns whether the search prompt is active.
+func (d *DetailView) IsSearching() bool {
+	return d.searching
+}
+
+// ClearSearch drops the current search and its highlights. It reports
+// false if there was nothing to clear.
+func (d *DetailView) ClearSearch() bool {
+	if d.searchQuery == "" {
+		return false
+	}
+	d.searchInput.SetValue("")
+	d.setQuery("")
+	return true
+}
+
 // SelectedNavID returns the issue ID of the currently selected nav item,
 // or empty string if nothing is selected.
 func (d *DetailView) SelectedNavID() string {
@@ -119,6 +227,12 @@ func (d *DetailView) SelectedNavID() string {
 
 // Update handles key messages.
 func (d *DetailView) Update(msg tea.Msg) tea.Cmd {
+	if d.searching {
+		return d.updateSearching(msg)
+	}
+	if d.editingNote {
+		return d.updateNote(msg)
+	}
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -156,12 +270,32 @@ func (d *DetailView) Update(msg tea.Msg) tea.Cmd {
 				}
 				d.scrollToNav()
 			}
-		case "enter":
-			if id := d.SelectedNavID(); id != "" {
+		case "enter", " ":
+			if cmd := d.toggleTask(); cmd != nil {
+				return cmd
+			}
+			if id := d.SelectedNavID(); id != "" && msg.String() == "enter" {
 				return func() tea.Msg {
 					return NavigateToIssueMsg{ID: id}
 				}
 			}
+		case "/":
+			d.searching = true
+			d.searchOrigin = d.scroll
+			d.searchInput.SetValue(d.searchQuery)
+			d.searchInput.CursorEnd()
+			d.searchInput.Focus()
+			return textinput.Blink
+		case "b":
+			d.editingNote = true
+			d.noteInput.SetValue(d.starNote)
+			d.noteInput.CursorEnd()
+			d.noteInput.Focus()
+			return textinput.Blink
+		case "n":
+			d.nextMatch(1)
+		case "N":
+			d.nextMatch(-1)
 		case "x":
 			d.toggleSection()
 		case "]":
@@ -173,6 +307,170 @@ func (d *DetailView) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// updateSearching handles keys while the search prompt is open. Matches
+// in expanded content update as you type; enter also searches collapsed
+// sections and expands the ones that match.
+func (d *DetailView) updateSearching(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			d.searching = false
+			d.searchInput.Blur()
+			d.expandMatches()
+			d.jumpToMatch()
+			return nil
+		case "esc":
+			d.searching = false
+			d.searchInput.Blur()
+			d.searchInput.SetValue("")
+			d.setQuery("")
+			d.scroll = d.searchOrigin
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	d.searchInput, cmd = d.searchInput.Update(msg)
+	if q := d.searchInput.Value(); q != d.searchQuery {
+		d.setQuery(q)
+		d.scroll = d.searchOrigin
+		d.jumpToMatch()
+	}
+	return cmd
+}
+
+// updateNote handles keys while the star note prompt is open. Enter saves
+// the note (starring the issue if needed); esc discards the edit.
+func (d *DetailView) updateNote(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			d.editingNote = false
+			d.noteInput.Blur()
+			id, note := d.IssueID(), strings.TrimSpace(d.noteInput.Value())
+			return func() tea.Msg { return StarNoteMsg{ID: id, Note: note} }
+		case "esc":
+			d.editingNote = false
+			d.noteInput.Blur()
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	d.noteInput, cmd = d.noteInput.Update(msg)
+	return cmd
+}
+
+// setQuery compiles the search query and re-finds matches. The search is
+// case-insensitive unless the query has an upper-case letter.
+func (d *DetailView) setQuery(q string) {
+	d.searchQuery = q
+	d.searchRe = nil
+	if q != "" {
+		pattern := regexp.QuoteMeta(q)
+		if !strings.ContainsFunc(q, unicode.IsUpper) {
+			pattern = "(?i)" + pattern
+		}
+		d.searchRe = regexp.MustCompile(pattern)
+	}
+	d.matchCursor = 0
+	d.findMatches()
+}
+
+// findMatches scans the rendered lines for the current query.
+func (d *DetailView) findMatches() {
+	d.matches = nil
+	d.matchLines = nil
+	if d.searchRe == nil {
+		return
+	}
+	d.matchLines = make(map[int][]int)
+	for i, line := range d.lines {
+		for _, loc := range d.searchRe.FindAllStringIndex(ui.Plain(line), -1) {
+			d.matchLines[i] = append(d.matchLines[i], len(d.matches))
+			d.matches = append(d.matches, searchMatch{line: i, match: ui.Match{Start: loc[0], End: loc[1]}})
+		}
+	}
+	if d.matchCursor >= len(d.matches) {
+		d.matchCursor = max(0, len(d.matches)-1)
+	}
+}
+
+// expandMatches expands collapsed sections that contain a match. It
+// renders everything expanded to find them, then restores the rest.
+func (d *DetailView) expandMatches() {
+	if d.searchRe == nil {
+		return
+	}
+	saved := d.collapsed
+	d.collapsed = [sectionCount]bool{}
+	d.buildContent()
+	for _, m := range d.matches {
+		for k := len(d.sectionLines) - 1; k >= 0; k-- {
+			if m.line >= d.sectionLines[k] {
+				saved[d.sections[k]] = false
+				break
+			}
+		}
+	}
+	d.collapsed = saved
+	d.buildContent()
+}
+
+// jumpToMatch selects the first match at or below the scroll position and
+// scrolls to it.
+func (d *DetailView) jumpToMatch() {
+	if len(d.matches) == 0 {
+		return
+	}
+	d.matchCursor = 0
+	for i, m := range d.matches {
+		if m.line >= d.scroll {
+			d.matchCursor = i
+			break
+		}
+	}
+	d.scrollToMatch()
+}
+
+// nextMatch moves to the next (delta 1) or previous (-1) match, wrapping.
+func (d *DetailView) nextMatch(delta int) {
+	if len(d.matches) == 0 {
+		return
+	}
+	d.matchCursor = (d.matchCursor + delta + len(d.matches)) % len(d.matches)
+	d.scrollToMatch()
+}
+
+// scrollToMatch brings the current match into view, a third of the way
+// down the screen if it was off-screen.
+func (d *DetailView) scrollToMatch() {
+	line := d.matches[d.matchCursor].line
+	vis := d.visibleLines()
+	if line < d.scroll || line >= d.scroll+vis {
+		d.scroll = line - vis/3
+	}
+	d.scroll = max(0, min(d.scroll, len(d.lines)-vis))
+}
+
+// toggleTask flips the checkbox under the nav cursor, updating the view
+// right away, and asks the app to save it. It returns nil if the cursor
+// isn't on a checkbox.
+func (d *DetailView) toggleTask() tea.Cmd {
+	if d.navCursor < 0 || d.navCursor >= len(d.navItems) || d.navItems[d.navCursor].task == nil {
+		return nil
+	}
+	task := d.navItems[d.navCursor].task
+	criteria, ok := models.ToggleChecklistItem(d.issue.AcceptanceCriteria, task.Line)
+	if !ok {
+		return nil
+	}
+	updated := *d.issue
+	updated.AcceptanceCriteria = criteria
+	d.UpdateIssue(&updated)
+
+	msg := ToggleChecklistMsg{ID: updated.ID, Line: task.Line, Item: task.Text, Done: !task.Done}
+	return func() tea.Msg { return msg }
+}
+
 // toggleSection toggles collapse on the section under the section cursor.
 func (d *DetailView) toggleSection() {
 	if len(d.sections) == 0 {
@@ -239,7 +537,14 @@ func (d *DetailView) scrollToNav() {
 }
 
 func (d *DetailView) visibleLines() int {
-	return ui.ContentHeight(d.height, d.renderHeaderChrome(), d.renderStatusBar())
+	chrome := []string{d.renderHeaderChrome()}
+	if !d.preview {
+		chrome = append(chrome, d.renderStatusBar())
+	}
+	if d.searching || d.editingNote {
+		chrome = append(chrome, " ")
+	}
+	return ui.ContentHeight(d.height, chrome...)
 }
 
 // View renders the detail view.
@@ -255,6 +560,19 @@ func (d *DetailView) View() string {
 	b.WriteString(d.renderContent())
 	b.WriteString("\n")
 
+	if d.searching {
+		b.WriteString(ui.FilterPromptStyle.Render("/") + " " + d.searchInput.View())
+		b.WriteString("\n")
+	}
+	if d.editingNote {
+		b.WriteString(starStyle.Render("★ note:") + " " + d.noteInput.View())
+		b.WriteString("\n")
+	}
+
+	if d.preview {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+
 	// Status bar
 	b.WriteString(d.renderStatusBar())
 
@@ -274,7 +592,22 @@ func (d *DetailView) renderHeaderChrome() string {
 		ui.StatusStyle(issue.Status).Render(issue.Status),
 		ui.TypeStyle(issue.IssueType).Render(issue.IssueType),
 	)
-	return ui.HeaderStyle.Width(d.width).Render(left)
+	if d.starred {
+		left += "  " + starStyle.Render("★")
+	}
+	return ui.HeaderStyle.Width(d.width).Render(left + d.renderStarNote())
+}
+
+// starStyle marks the user's personal stars.
+var starStyle = lipgloss.NewStyle().Foreground(ui.ColorMagenta)
+
+// renderStarNote returns the private star note as an extra header line,
+// or "" if there is none.
+func (d *DetailView) renderStarNote() string {
+	if !d.starred || d.starNote == "" {
+		return ""
+	}
+	return "\n" + starStyle.Render("★ "+ui.Truncate(d.starNote, max(10, d.width-6)))
 }
 
 func (d *DetailView) renderHeader(vis int) string {
@@ -288,6 +621,10 @@ func (d *DetailView) renderHeader(vis int) string {
 	status := ui.StatusStyle(issue.Status).Render(issue.Status)
 	itype := ui.TypeStyle(issue.IssueType).Render(issue.IssueType)
 
+	if d.starred {
+		itype += "  " + starStyle.Render("★")
+	}
+
 	left := fmt.Sprintf("%s  %s  %s  %s", id, pri, status, itype)
 
 	// Breadcrumb trail: show navigation path when drilled into deps.
@@ -312,9 +649,17 @@ func (d *DetailView) renderHeader(vis int) string {
 		}
 		scrollInfo = ui.KeyDescStyle.Render(fmt.Sprintf("[%d%%]", pct))
 	}
+	if d.searchQuery != "" {
+		info := "no matches"
+		if len(d.matches) > 0 {
+			info = fmt.Sprintf("%d/%d", d.matchCursor+1, len(d.matches))
+		}
+		search := ui.FilterPromptStyle.Render("/"+ui.Truncate(d.searchQuery, 20)) + " " + ui.KeyDescStyle.Render(info)
+		scrollInfo = strings.TrimSpace(search + "  " + scrollInfo)
+	}
 
 	gap := max(0, d.width-lipgloss.Width(left)-lipgloss.Width(scrollInfo)-2)
-	header := left + strings.Repeat(" ", gap) + scrollInfo
+	header := left + strings.Repeat(" ", gap) + scrollInfo + d.renderStarNote()
 
 	return ui.HeaderStyle.Width(d.width).Render(header)
 }
@@ -380,7 +725,7 @@ func (d *DetailView) buildContent() {
 		field("Estimate", est)
 	}
 	if lt := issue.LeadTime(); lt > 0 {
-		field("Lead Time", formatDuration(lt))
+		field("Lead Time", models.FormatDuration(lt))
 	}
 
 	// Parent — navigable link to drill up the hierarchy.
@@ -414,7 +759,7 @@ func (d *DetailView) buildContent() {
 	if issue.Description != "" {
 		addSectionHeader(sectionDescription, "DESCRIPTION")
 		if !d.collapsed[sectionDescription] {
-			for _, line := range wrapText(issue.Description, contentWidth) {
+			for _, line := range d.renderText(issue.Description, contentWidth) {
 				add(line)
 			}
 		}
@@ -424,19 +769,28 @@ func (d *DetailView) buildContent() {
 	if issue.Design != "" {
 		addSectionHeader(sectionDesign, "DESIGN")
 		if !d.collapsed[sectionDesign] {
-			for _, line := range wrapText(issue.Design, contentWidth) {
+			for _, line := range d.renderText(issue.Design, contentWidth) {
 				add(line)
 			}
 		}
 	}
 
-	// Acceptance Criteria
+	// Acceptance Criteria — checklist items are navigable and toggleable.
 	if issue.AcceptanceCriteria != "" {
-		addSectionHeader(sectionAcceptance, "ACCEPTANCE CRITERIA")
+		items := models.ParseChecklist(issue.AcceptanceCriteria)
+		title := "ACCEPTANCE CRITERIA"
+		if p := models.Progress(items); p.Total > 0 {
+			title += fmt.Sprintf(" (%s)", p)
+		}
+		addSectionHeader(sectionAcceptance, title)
 		if !d.collapsed[sectionAcceptance] {
-			for _, line := range wrapText(issue.AcceptanceCriteria, contentWidth) {
-				add(line)
+			rendered, taskLines := d.renderTasks(issue.AcceptanceCriteria, contentWidth)
+			for i := range items {
+				if at, ok := taskLines[items[i].Line]; ok {
+					navItems = append(navItems, navItem{lineIndex: len(lines) + at, task: &items[i]})
+				}
 			}
+			lines = append(lines, rendered...)
 		}
 	}
 
@@ -444,7 +798,7 @@ func (d *DetailView) buildContent() {
 	if issue.Notes != "" {
 		addSectionHeader(sectionNotes, "NOTES")
 		if !d.collapsed[sectionNotes] {
-			for _, line := range wrapText(issue.Notes, contentWidth) {
+			for _, line := range d.renderText(issue.Notes, contentWidth) {
 				add(line)
 			}
 		}
@@ -503,7 +857,7 @@ func (d *DetailView) buildContent() {
 				author := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorCyan).Render(c.Author)
 				age := models.RelativeAge(c.CreatedAt)
 				add(fmt.Sprintf("  %s (%s ago):", author, age))
-				for _, line := range wrapText(c.Text, contentWidth-4) {
+				for _, line := range d.renderText(c.Text, contentWidth-4) {
 					add("    " + line)
 				}
 				addBlank()
@@ -515,6 +869,7 @@ func (d *DetailView) buildContent() {
 	d.sections = sections
 	d.sectionLines = sectionLines
 	d.navItems = navItems
+	d.findMatches()
 	// Clamp nav cursor
 	if d.navCursor >= len(d.navItems) {
 		d.navCursor = max(-1, len(d.navItems)-1)
@@ -577,6 +932,17 @@ func (d *DetailView) renderContent() string {
 	visible := make([]string, 0, vis)
 	for i := start; i < end; i++ {
 		line := d.lines[i]
+		if idx := d.matchLines[i]; len(idx) > 0 {
+			ms := make([]ui.Match, len(idx))
+			current := -1
+			for j, m := range idx {
+				ms[j] = d.matches[m].match
+				if m == d.matchCursor {
+					current = j
+				}
+			}
+			line = ui.Highlight(line, ms, current)
+		}
 		if i == navHighlight {
 			line = ui.SelectedRowStyle.Width(d.width - 4).Render(line)
 		} else if i == sectionHighlight {
@@ -603,14 +969,22 @@ func (d *DetailView) renderStatusBar() string {
 		{"j/k", "scroll"},
 		{"[/]", "section"},
 		{"x", "collapse"},
-		{"tab", "next dep"},
+		{"M", "raw/markdown"},
+		{"/", "search"},
+		{"tab", "next link"},
 		{"enter", "drill in"},
+		{"space", "check"},
 		{"g/G", "top/bottom"},
 		{"r", "refresh"},
 		{"y", "copy ID"},
+		{"*", "star"},
+		{"b", "star note"},
 		{"?", "help"},
 		{"q", "quit"},
 	}
+	if d.searchQuery != "" {
+		keys = append([]struct{ key, desc string }{{"n/N", "next/prev match"}, {"esc", "clear search"}}, keys[1:]...)
+	}
 	var parts []string
 	for _, k := range keys {
 		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
@@ -619,21 +993,26 @@ func (d *DetailView) renderStatusBar() string {
 	return ui.StatusBarStyle.Width(d.width).Render(bar)
 }
 
-// formatDuration formats a duration as a human-readable string like "2d 4h".
-func formatDuration(d time.Duration) string {
-	hours := int(d.Hours())
-	if hours < 1 {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	}
-	if hours < 24 {
-		return fmt.Sprintf("%dh", hours)
+// renderText renders a long-text field as markdown, or wraps the raw source
+// when markdown rendering is toggled off.
+func (d *DetailView) renderText(text string, width int) []string {
+	lines, _ := d.renderTasks(text, width)
+	return lines
+}
+
+// renderTasks is renderText that also maps the source line of each
+// task-list item to its first rendered line.
+func (d *DetailView) renderTasks(text string, width int) ([]string, map[int]int) {
+	if !d.rawMarkdown {
+		return markdown.RenderTasks(text, width)
 	}
-	days := hours / 24
-	rh := hours % 24
-	if rh > 0 {
-		return fmt.Sprintf("%dd %dh", days, rh)
+	var lines []string
+	tasks := make(map[int]int)
+	for i, src := range strings.Split(text, "\n") {
+		tasks[i] = len(lines)
+		lines = append(lines, wrapText(src, width)...)
 	}
-	return fmt.Sprintf("%dd", days)
+	return lines, tasks
 }
 
 // wrapText splits text into lines, preserving existing newlines
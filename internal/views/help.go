@@ -42,6 +42,7 @@ func (h *HelpView) View() string {
 				{"Enter", "Open issue detail / drill into dependency"},
 				{"Tab / Shift+Tab", "Cycle through dependencies (detail view)"},
 				{"Esc", "Back / cancel"},
+				{"H / L", "History back / forward (also alt+left / alt+right)"},
 			},
 		},
 		{
@@ -63,15 +64,36 @@ func (h *HelpView) View() string {
 				{"5", "Toggle: ready (unblocked) only"},
 				{"6", "Toggle: deferred only"},
 				{"7", "Toggle: pinned only"},
+				{"8", "Toggle: starred only"},
 				{"0", "Show all statuses"},
 				{"c", "Toggle: show/hide closed issues (hidden by default)"},
 			},
 		},
+		{
+			header: "Views",
+			keys: []struct{ key, desc string }{
+				{"a", "Activity feed of changes detected this session"},
+				{"1-6 / 0", "Activity: toggle kind filter / show all kinds"},
+				{"n", "Next-task picker: ranked ready queue (c claims selected)"},
+				{"p", "Critical path and top blockers of an epic"},
+				{"C", "Agenda and month calendar of due and wake-up dates"},
+				{"f", "Full-text search of descriptions, notes and comments"},
+				{"v", "Split pane with live preview (< > resize, J / K scroll preview)"},
+				{"m", "Metrics: burndown, flow, throughput, lead time (s scope, w window, tab report)"},
+				{"N", "Notification center (watches and recent notifications)"},
+				{"R", "History picker: recently viewed issues, across sessions"},
+				{"B", "Starred saved view: all your starred issues, newest update first"},
+			},
+		},
 		{
 			header: "Detail View",
 			keys: []struct{ key, desc string }{
 				{"[ / ]", "Move section cursor up / down"},
 				{"x", "Collapse / expand section under cursor"},
+				{"M", "Toggle rendered markdown / raw source"},
+				{"/", "Search text (n / N next / previous, esc clears)"},
+				{"space", "Toggle acceptance checkbox under cursor (tab to select)"},
+				{"b", "Edit the private note on your star"},
 			},
 		},
 		{
@@ -79,7 +101,11 @@ func (h *HelpView) View() string {
 			keys: []struct{ key, desc string }{
 				{"r", "Refresh data from bd"},
 				{"y", "Copy issue ID to clipboard"},
+				{"w", "Watch / unwatch issue (notify on changes)"},
+				{"*", "Star / unstar issue (personal, not shared)"},
 				{"?", "Toggle this help screen"},
+				{"E", "Refresh errors (when the header warns of failed refreshes)"},
+				{"D", "Diagnostics: refresh and watcher state, bd commands, errors"},
 				{"q", "Quit"},
 			},
 		},
@@ -0,0 +1,223 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// HistoryView is the history picker: recently viewed issues, newest
+// first, including those from earlier sessions.
+type HistoryView struct {
+	recent []config.RecentIssue
+	issues map[string]*models.Issue
+	cursor int
+	offset int
+	width  int
+	height int
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewHistoryView creates an empty history picker.
+func NewHistoryView() *HistoryView {
+	return &HistoryView{}
+}
+
+// SetRecent replaces the recent issues and moves the cursor to the top.
+func (h *HistoryView) SetRecent(recent []config.RecentIssue) {
+	h.recent = recent
+	h.cursor = 0
+	h.offset = 0
+}
+
+// SetData updates the current issue metadata shown next to each entry.
+func (h *HistoryView) SetData(issues []models.Issue) {
+	h.issues = make(map[string]*models.Issue, len(issues))
+	for i := range issues {
+		h.issues[issues[i].ID] = &issues[i]
+	}
+}
+
+// SetSize sets terminal dimensions.
+func (h *HistoryView) SetSize(w, ht int) {
+	h.width = w
+	h.height = ht
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (h *HistoryView) SetStatusMsg(msg string) {
+	h.statusMsg = msg
+}
+
+// SelectedID returns the issue ID under the cursor, or "".
+func (h *HistoryView) SelectedID() string {
+	if h.cursor >= len(h.recent) {
+		return ""
+	}
+	return h.recent[h.cursor].ID
+}
+
+// Update handles key messages for the picker.
+func (h *HistoryView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if h.cursor < len(h.recent)-1 {
+				h.cursor++
+				h.ensureVisible()
+			}
+		case "k", "up":
+			if h.cursor > 0 {
+				h.cursor--
+				h.ensureVisible()
+			}
+		case "g", "home":
+			h.cursor = 0
+			h.offset = 0
+		case "G", "end":
+			h.cursor = max(0, len(h.recent)-1)
+			h.ensureVisible()
+		case "enter":
+			if id := h.SelectedID(); id != "" {
+				return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+			}
+		}
+	}
+	return nil
+}
+
+func (h *HistoryView) visibleRows() int {
+	tblHdr := ui.TableHeaderStyle.Width(h.width).Render("")
+	return ui.ContentHeight(h.height, h.renderHeader(), tblHdr, h.renderStatusBar())
+}
+
+func (h *HistoryView) ensureVisible() {
+	vis := h.visibleRows()
+	if h.cursor < h.offset {
+		h.offset = h.cursor
+	}
+	if h.cursor >= h.offset+vis {
+		h.offset = h.cursor - vis + 1
+	}
+}
+
+// View renders the picker.
+func (h *HistoryView) View() string {
+	var b strings.Builder
+	b.WriteString(h.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(h.renderTable())
+	b.WriteString("\n")
+	b.WriteString(h.renderStatusBar())
+	return b.String()
+}
+
+func (h *HistoryView) renderHeader() string {
+	left := ui.LogoStyle.Render("history") + "  " + fmt.Sprintf("%d recently viewed", len(h.recent))
+	gap := max(0, h.width-lipgloss.Width(left)-2)
+	return ui.HeaderStyle.Width(h.width).Render(left + strings.Repeat(" ", gap))
+}
+
+func (h *HistoryView) renderTable() string {
+	if len(h.recent) == 0 {
+		emptyHeight := max(1, h.height-6)
+		return strings.Repeat("\n", emptyHeight/2) + lipgloss.NewStyle().
+			Width(h.width).
+			Align(lipgloss.Center).
+			Foreground(ui.ColorGray).
+			Render("No issues viewed yet.")
+	}
+
+	tbl := ui.NewTable(
+		&ui.Column{Header: "ID", Size: ui.SizeFit, Align: ui.AlignLeft, Min: 4, Max: 20},
+		&ui.Column{Header: "PRI", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 3},
+		&ui.Column{Header: "STATUS", Size: ui.SizeFit, Align: ui.AlignLeft, Min: 6, Max: 11},
+		&ui.Column{Header: "TITLE", Size: ui.SizeFlex, Align: ui.AlignLeft, Min: 10},
+		&ui.Column{Header: "VIEWED", Size: ui.SizeFixed, Align: ui.AlignRight, Fixed: 6},
+	)
+	dataWidths := make([]int, 5)
+	for _, r := range h.recent {
+		dataWidths[0] = max(dataWidths[0], ui.StringWidth(r.ID))
+		if issue := h.issues[r.ID]; issue != nil {
+			dataWidths[2] = max(dataWidths[2], ui.StringWidth(issue.Status))
+		}
+	}
+	tbl.Resolve(h.width-2, dataWidths)
+
+	headers := make([]string, len(tbl.Columns))
+	for i, col := range tbl.Columns {
+		headers[i] = col.Header
+	}
+	rows := []string{ui.TableHeaderStyle.Width(h.width).Render("  " + tbl.RenderRow(headers, nil))}
+
+	vis := h.visibleRows()
+	end := min(h.offset+vis, len(h.recent))
+	for i := h.offset; i < end; i++ {
+		r := h.recent[i]
+		selected := i == h.cursor
+		cursor := "  "
+		if selected {
+			cursor = "> "
+		}
+		// Prefer the current title; the saved one covers issues that have
+		// since been deleted.
+		issue := h.issues[r.ID]
+		cells := []string{r.ID, "", "gone", r.Title, models.RelativeAge(r.Viewed)}
+		if issue != nil {
+			cells[1] = issue.PriorityString()
+			cells[2] = issue.Status
+			cells[3] = issue.Title
+		}
+		styleFn := func(col int, padded string) string {
+			switch {
+			case col == 1 && issue != nil:
+				return ui.PriorityStyle(issue.Priority).Render(padded)
+			case col == 2 && issue != nil:
+				return ui.StatusStyle(issue.Status).Render(padded)
+			case col == 2 || col == 4:
+				return lipgloss.NewStyle().Foreground(ui.ColorGray).Render(padded)
+			default:
+				return padded
+			}
+		}
+		row := cursor + tbl.RenderRow(cells, styleFn)
+		if selected {
+			row = ui.SelectedRowStyle.Width(h.width).Render(row)
+		}
+		rows = append(rows, row)
+	}
+	for len(rows)-1 < vis {
+		rows = append(rows, strings.Repeat(" ", h.width))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (h *HistoryView) renderStatusBar() string {
+	if h.statusMsg != "" {
+		return ui.StatusBarStyle.Width(h.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(h.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"enter", "view"},
+		{"j/k", "move"},
+		{"H/L", "back/forward"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(h.width).Render(strings.Join(parts, "  "))
+}
@@ -67,6 +67,7 @@ const (
 	FilterReady
 	FilterDeferred
 	FilterPinned
+	FilterStarred
 )
 
 func (f StatusFilter) String() string {
@@ -87,11 +88,34 @@ func (f StatusFilter) String() string {
 		return "deferred"
 	case FilterPinned:
 		return "pinned"
+	case FilterStarred:
+		return "starred"
 	default:
 		return "all"
 	}
 }
 
+// WatchHealth is how the app learns about database changes, shown in the
+// list header.
+type WatchHealth int
+
+const (
+	WatchLive     WatchHealth = iota // file events, with polling as a backstop
+	WatchPolling                     // polling only
+	WatchDisabled                    // no database found; manual refresh only
+)
+
+func (h WatchHealth) String() string {
+	switch h {
+	case WatchLive:
+		return "live"
+	case WatchPolling:
+		return "polling"
+	default:
+		return "no auto-refresh"
+	}
+}
+
 // ListView is the main list view model.
 type ListView struct {
 	allIssues    []models.Issue
@@ -109,16 +133,33 @@ type ListView struct {
 	filtering    bool
 	filterText   string
 	stats        *models.StatsSummary
+	stars        map[string]bool // the user's personal stars (issue IDs)
 
 	// Change tracking for pulse flare on updated rows.
-	prevUpdatedAt map[string]time.Time // issue ID -> UpdatedAt from last data load
-	flashIDs      map[string]bool      // issue IDs currently flashing
+	prevIssues map[string]models.Issue       // issue ID -> snapshot from last data load
+	flashes    map[string]models.IssueChange // issue ID -> change currently flashing
 
 	// Completion tracking for epics/parents.
-	closedChildrenCount map[string]int // parent ID -> count of closed children
+	closedChildrenCount map[string]int                      // parent ID -> count of closed children
+	checklists          map[string]models.ChecklistProgress // issue ID -> acceptance checklist progress
+	bodyChecklists      map[string]models.ChecklistProgress // same, from full issues (see UpdateChecklists)
 
 	// Temporary status message shown in the status bar.
 	statusMsg string
+
+	// Unread watch notifications, shown in the header.
+	unreadNotifications int
+
+	// Database watcher health, shown in the header.
+	watchHealth WatchHealth
+
+	// Background refreshes failing in a row, and when the data shown was
+	// loaded; the header warns while failures > 0.
+	refreshFailures int
+	loadedAt        time.Time
+
+	// Newer release found by the update check, "" if none.
+	updateAvailable string
 }
 
 // NewListView creates a new list view.
@@ -132,33 +173,30 @@ func NewListView() *ListView {
 		hideClosed:          true,
 		filterInput:         ti,
 		readyIDs:            make(map[string]bool),
-		prevUpdatedAt:       make(map[string]time.Time),
-		flashIDs:            make(map[string]bool),
+		prevIssues:          make(map[string]models.Issue),
+		flashes:             make(map[string]models.IssueChange),
 		closedChildrenCount: make(map[string]int),
 	}
 }
 
 // SetData updates the issue list and stats.
-// Returns true if any issues changed (and row flashes were triggered).
-func (l *ListView) SetData(issues []models.Issue, readyIssues []models.Issue, stats *models.StatsSummary) bool {
-	// Detect changed rows by comparing UpdatedAt timestamps.
-	// New issues (not in prevUpdatedAt) and issues with changed timestamps
-	// are both caught by the !existed || !Equal check.
-	hasFlashes := false
-	if len(l.prevUpdatedAt) > 0 {
-		for _, issue := range issues {
-			prev, existed := l.prevUpdatedAt[issue.ID]
-			if !existed || !issue.UpdatedAt.Equal(prev) {
-				l.flashIDs[issue.ID] = true
-				hasFlashes = true
-			}
+// Returns the changes detected since the previous load (empty on the first
+// load); changed rows are flashed.
+func (l *ListView) SetData(issues []models.Issue, readyIssues []models.Issue, stats *models.StatsSummary) []models.IssueChange {
+	// Detect changed rows by comparing against the previous snapshots.
+	// New issues and issues with a moved UpdatedAt are both reported.
+	var changes []models.IssueChange
+	if len(l.prevIssues) > 0 {
+		changes = models.DetectChanges(l.prevIssues, issues)
+		for _, c := range changes {
+			l.flashes[c.IssueID] = c
 		}
 	}
 
-	// Update the index for next comparison.
-	l.prevUpdatedAt = make(map[string]time.Time, len(issues))
+	// Update the snapshots for next comparison.
+	l.prevIssues = make(map[string]models.Issue, len(issues))
 	for _, issue := range issues {
-		l.prevUpdatedAt[issue.ID] = issue.UpdatedAt
+		l.prevIssues[issue.ID] = issue
 	}
 
 	l.allIssues = issues
@@ -169,17 +207,15 @@ func (l *ListView) SetData(issues []models.Issue, readyIssues []models.Issue, st
 	}
 
 	// Calculate closed children counts for epics/parents using the dependency
-	// graph. Each child issue has a "parent-child" dependency pointing to its
-	// parent via depends_on_id. This is more accurate than ID pattern matching
-	// because children can have independent IDs (e.g., kubrick-0z4 is a child
-	// of kubrick-drj despite not matching the kubrick-drj.* pattern).
+	// graph.
 	l.closedChildrenCount = make(map[string]int)
-	for _, issue := range issues {
-		for _, dep := range issue.Dependencies {
-			if dep.DepTypeValue() == "parent-child" && issue.Status == "closed" {
-				l.closedChildrenCount[dep.ParentID()]++
-			}
-		}
+	for i := range issues {
+		l.countClosedChildren(&issues[i], 1)
+	}
+
+	l.checklists = make(map[string]models.ChecklistProgress)
+	for i := range issues {
+		l.setChecklist(&issues[i])
 	}
 
 	l.applyFilterAndSort()
@@ -187,12 +223,130 @@ func (l *ListView) SetData(issues []models.Issue, readyIssues []models.Issue, st
 	if l.cursor >= len(l.filtered) {
 		l.cursor = max(0, len(l.filtered)-1)
 	}
-	return hasFlashes
+	return changes
+}
+
+// MergeData applies an incremental refresh. issues is the full list with
+// the changed issues already merged in, and readyIDs the current ready
+// set. Only the changed issues' derived data is recomputed. Changes are
+// reported as by SetData.
+func (l *ListView) MergeData(issues, changed []models.Issue, readyIDs map[string]bool, stats *models.StatsSummary) []models.IssueChange {
+	changes := models.DetectChanges(l.prevIssues, changed)
+	for _, c := range changes {
+		l.flashes[c.IssueID] = c
+	}
+	for i := range changed {
+		cur := &changed[i]
+		if prev, ok := l.prevIssues[cur.ID]; ok {
+			l.countClosedChildren(&prev, -1)
+		}
+		l.countClosedChildren(cur, 1)
+		l.prevIssues[cur.ID] = *cur
+		delete(l.checklists, cur.ID)
+		l.setChecklist(cur)
+	}
+
+	l.allIssues = issues
+	l.stats = stats
+	l.readyIDs = readyIDs
+
+	l.applyFilterAndSort()
+	if l.cursor >= len(l.filtered) {
+		l.cursor = max(0, len(l.filtered)-1)
+	}
+	return changes
+}
+
+// countClosedChildren adds n to the closed child count of a closed
+// issue's parents. Each child issue has a "parent-child" dependency
+// pointing to its parent via depends_on_id. This is more accurate than ID
+// pattern matching because children can have independent IDs (e.g.,
+// kubrick-0z4 is a child of kubrick-drj despite not matching the
+// kubrick-drj.* pattern).
+func (l *ListView) countClosedChildren(issue *models.Issue, n int) {
+	if issue.Status != "closed" {
+		return
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.DepTypeValue() == "parent-child" {
+			l.closedChildrenCount[dep.ParentID()] += n
+		}
+	}
+}
+
+// setChecklist records an issue's acceptance checklist progress, falling
+// back to what bd show reported when bd list omits the criteria.
+func (l *ListView) setChecklist(issue *models.Issue) {
+	if p := issue.AcceptanceProgress(); p.Total > 0 {
+		l.checklists[issue.ID] = p
+	} else if p, ok := l.bodyChecklists[issue.ID]; ok && issue.AcceptanceCriteria == "" {
+		l.checklists[issue.ID] = p
+	}
+}
+
+// UpdateChecklists records acceptance checklist progress from full issues
+// (bd show output). bd list may omit acceptance criteria, so this is how
+// the AC column learns about issues that have been opened or indexed.
+func (l *ListView) UpdateChecklists(issues []models.Issue) {
+	if l.bodyChecklists == nil {
+		l.bodyChecklists = make(map[string]models.ChecklistProgress)
+	}
+	if l.checklists == nil {
+		l.checklists = make(map[string]models.ChecklistProgress)
+	}
+	for i := range issues {
+		id := issues[i].ID
+		if p := issues[i].AcceptanceProgress(); p.Total > 0 {
+			l.bodyChecklists[id] = p
+			l.checklists[id] = p
+		} else {
+			delete(l.bodyChecklists, id)
+			delete(l.checklists, id)
+		}
+	}
 }
 
 // ClearFlashes removes all active row flashes.
 func (l *ListView) ClearFlashes() {
-	l.flashIDs = make(map[string]bool)
+	l.flashes = make(map[string]models.IssueChange)
+}
+
+// fieldColumns maps a models.FieldChange field name to the list column that
+// displays it. Fields without a column of their own flash the ID cell.
+var fieldColumns = map[string]int{
+	"title":    colIdxTitle,
+	"status":   colIdxStatus,
+	"priority": colIdxPri,
+	"type":     colIdxType,
+	"assignee": colIdxAssignee,
+	"due":      colIdxDue,
+	"comments": colIdxCmt,
+	"deps":     colIdxDeps,
+	"criteria": colIdxChecklist,
+	"pinned":   colIdxID,
+}
+
+// flashColumns returns the set of column indices to highlight for a change.
+// New issues flash every cell.
+func flashColumns(c models.IssueChange) map[int]bool {
+	cols := make(map[int]bool)
+	if c.Kind == models.ChangeCreated {
+		for i := colIdxID; i <= colIdxChecklist; i++ {
+			cols[i] = true
+		}
+		return cols
+	}
+	for _, f := range c.Fields {
+		if col, ok := fieldColumns[f.Field]; ok {
+			cols[col] = true
+		} else {
+			cols[colIdxID] = true
+		}
+	}
+	if len(cols) == 0 {
+		cols[colIdxID] = true
+	}
+	return cols
 }
 
 // SetSize sets the terminal dimensions.
@@ -209,16 +363,149 @@ func (l *ListView) SelectedIssue() *models.Issue {
 	return &l.filtered[l.cursor]
 }
 
+// IDsAround returns the IDs of the selected issue and up to radius rows
+// above and below it, nearest first.
+func (l *ListView) IDsAround(radius int) []string {
+	if len(l.filtered) == 0 || l.cursor >= len(l.filtered) {
+		return nil
+	}
+	ids := []string{l.filtered[l.cursor].ID}
+	for d := 1; d <= radius; d++ {
+		if i := l.cursor + d; i < len(l.filtered) {
+			ids = append(ids, l.filtered[i].ID)
+		}
+		if i := l.cursor - d; i >= 0 {
+			ids = append(ids, l.filtered[i].ID)
+		}
+	}
+	return ids
+}
+
 // SetStatusMsg sets a temporary status bar message.
 func (l *ListView) SetStatusMsg(msg string) {
 	l.statusMsg = msg
 }
 
+// SetWatchHealth sets the database watcher health shown in the header.
+func (l *ListView) SetWatchHealth(h WatchHealth) {
+	l.watchHealth = h
+}
+
+// SetRefreshFailures sets how many background refreshes have failed in a
+// row and when the data shown was last loaded successfully. The header
+// shows a warning while n > 0.
+func (l *ListView) SetRefreshFailures(n int, loadedAt time.Time) {
+	l.refreshFailures = n
+	l.loadedAt = loadedAt
+}
+
+// SetUpdateAvailable sets the newer release shown in the header, or ""
+// to hide it.
+func (l *ListView) SetUpdateAvailable(version string) {
+	l.updateAvailable = version
+}
+
+// SetUnreadNotifications sets the unread notification count shown in the header.
+func (l *ListView) SetUnreadNotifications(n int) {
+	l.unreadNotifications = n
+}
+
 // IsFiltering returns whether the filter input is active.
 func (l *ListView) IsFiltering() bool {
 	return l.filtering
 }
 
+// SetStars replaces the set of starred issue IDs, which feeds the ID
+// marker, the starred filter and the sort order. The cursor stays on the
+// selected issue if it is still shown.
+func (l *ListView) SetStars(stars map[string]bool) {
+	selected := ""
+	if issue := l.SelectedIssue(); issue != nil {
+		selected = issue.ID
+	}
+	l.stars = stars
+	l.applyFilterAndSort()
+	l.cursor = min(l.cursor, max(0, len(l.filtered)-1))
+	for i := range l.filtered {
+		if l.filtered[i].ID == selected {
+			l.cursor = i
+			break
+		}
+	}
+	l.ensureVisible()
+}
+
+// idDisplay returns the ID cell: the issue ID behind a "*" when pinned
+// and a "★" when starred.
+func (l *ListView) idDisplay(issue models.Issue) string {
+	marker := ""
+	if issue.Pinned {
+		marker += "*"
+	}
+	if l.stars[issue.ID] {
+		marker += "★"
+	}
+	if marker == "" {
+		return issue.ID
+	}
+	return marker + " " + issue.ID
+}
+
+// ListState is a snapshot of the list's filters, sort and selection,
+// used for back/forward navigation.
+type ListState struct {
+	SortField    SortField
+	SortReverse  bool
+	StatusFilter StatusFilter
+	HideClosed   bool
+	FilterText   string
+	SelectedID   string
+}
+
+// SameView reports whether two states show the same rows in the same
+// order, whatever is selected.
+func (s ListState) SameView(o ListState) bool {
+	s.SelectedID, o.SelectedID = "", ""
+	return s == o
+}
+
+// State returns the list's current filters, sort and selection.
+func (l *ListView) State() ListState {
+	st := ListState{
+		SortField:    l.sortField,
+		SortReverse:  l.sortReverse,
+		StatusFilter: l.statusFilter,
+		HideClosed:   l.hideClosed,
+		FilterText:   l.filterText,
+	}
+	if issue := l.SelectedIssue(); issue != nil {
+		st.SelectedID = issue.ID
+	}
+	return st
+}
+
+// RestoreState reapplies a saved state, selecting the same issue if it is
+// still shown.
+func (l *ListView) RestoreState(st ListState) {
+	l.sortField = st.SortField
+	l.sortReverse = st.SortReverse
+	l.statusFilter = st.StatusFilter
+	l.hideClosed = st.HideClosed
+	l.filterText = st.FilterText
+	l.filterInput.SetValue(st.FilterText)
+	l.filtering = false
+	l.filterInput.Blur()
+	l.applyFilterAndSort()
+	l.cursor = min(l.cursor, max(0, len(l.filtered)-1))
+	for i := range l.filtered {
+		if l.filtered[i].ID == st.SelectedID {
+			l.cursor = i
+			break
+		}
+	}
+	l.ensureVisible()
+}
+
 // Update handles key messages for the list view.
 func (l *ListView) Update(msg tea.Msg) tea.Cmd {
 	if l.filtering {
@@ -272,6 +559,8 @@ func (l *ListView) Update(msg tea.Msg) tea.Cmd {
 			l.toggleStatusFilter(FilterDeferred)
 		case "7":
 			l.toggleStatusFilter(FilterPinned)
+		case "8":
+			l.toggleStatusFilter(FilterStarred)
 		case "0":
 			l.statusFilter = FilterAll
 			l.applyFilterAndSort()
@@ -350,6 +639,29 @@ func (l *ListView) applyFilterAndSort() {
 	l.filtered = filtered
 }
 
+// FilterScope returns all issues matching the current status and text
+// filters, including closed ones regardless of the hide-closed toggle, and
+// a short description of the filter. Used to scope aggregate views.
+func (l *ListView) FilterScope() ([]models.Issue, string) {
+	var scoped []models.Issue
+	for _, issue := range l.allIssues {
+		if l.matchesStatusFilter(issue) && l.matchesTextFilter(issue) {
+			scoped = append(scoped, issue)
+		}
+	}
+	var desc []string
+	if l.statusFilter != FilterAll {
+		desc = append(desc, l.statusFilter.String())
+	}
+	if l.filterText != "" {
+		desc = append(desc, fmt.Sprintf("%q", l.filterText))
+	}
+	if len(desc) == 0 {
+		return scoped, "all"
+	}
+	return scoped, strings.Join(desc, " ")
+}
+
 func (l *ListView) matchesStatusFilter(issue models.Issue) bool {
 	switch l.statusFilter {
 	case FilterAll:
@@ -368,6 +680,8 @@ func (l *ListView) matchesStatusFilter(issue models.Issue) bool {
 		return issue.Status == "deferred"
 	case FilterPinned:
 		return issue.Pinned
+	case FilterStarred:
+		return l.stars[issue.ID]
 	default:
 		return true
 	}
@@ -393,13 +707,20 @@ func (l *ListView) matchesTextFilter(issue models.Issue) bool {
 }
 
 func (l *ListView) compareIssues(a, b models.Issue) int {
-	// Pinned issues always sort above unpinned at the same level.
+	// Pinned issues always sort above unpinned at the same level, and
+	// starred ones above the rest.
 	if a.Pinned != b.Pinned {
 		if a.Pinned {
 			return -1
 		}
 		return 1
 	}
+	if sa, sb := l.stars[a.ID], l.stars[b.ID]; sa != sb {
+		if sa {
+			return -1
+		}
+		return 1
+	}
 
 	switch l.sortField {
 	case SortByPriority:
@@ -527,6 +848,32 @@ func (l *ListView) renderHeader() string {
 		}
 	}
 
+	if l.unreadNotifications > 0 {
+		parts = append(parts, lipgloss.NewStyle().Bold(true).Foreground(ui.ColorYellow).
+			Render(fmt.Sprintf("%d new notifications (N)", l.unreadNotifications)))
+	}
+
+	if l.updateAvailable != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ui.ColorCyan).
+			Render("update available: "+l.updateAvailable+" (bdy update)"))
+	}
+
+	if l.refreshFailures > 0 {
+		noun := "refresh"
+		if l.refreshFailures > 1 {
+			noun = "refreshes"
+		}
+		warn := fmt.Sprintf("⚠ %d failed %s", l.refreshFailures, noun)
+		switch {
+		case l.loadedAt.IsZero():
+		case time.Since(l.loadedAt) < time.Minute:
+			warn += ", data from just now"
+		default:
+			warn += ", data from " + models.RelativeAge(l.loadedAt) + " ago"
+		}
+		parts = append(parts, lipgloss.NewStyle().Bold(true).Foreground(ui.ColorRed).Render(warn+" (E)"))
+	}
+
 	info := strings.Join(parts, "  ")
 	sortInfo := ui.KeyStyle.Render("sort:") + " " + ui.KeyDescStyle.Render(l.sortField.String())
 	if l.sortReverse {
@@ -543,8 +890,16 @@ func (l *ListView) renderHeader() string {
 		filterInfo += "  " + ui.KeyDescStyle.Render("+closed")
 	}
 
+	health := lipgloss.NewStyle().Foreground(ui.ColorGreen).Render("● " + l.watchHealth.String())
+	switch l.watchHealth {
+	case WatchPolling:
+		health = lipgloss.NewStyle().Foreground(ui.ColorYellow).Render("◌ " + l.watchHealth.String())
+	case WatchDisabled:
+		health = lipgloss.NewStyle().Foreground(ui.ColorRed).Render("○ " + l.watchHealth.String())
+	}
+
 	left := logo + "  " + info
-	right := sortInfo + filterInfo
+	right := sortInfo + filterInfo + "  " + health
 	gap := max(0, l.width-lipgloss.Width(left)-lipgloss.Width(right)-2)
 	header := left + strings.Repeat(" ", gap) + right
 
@@ -564,6 +919,10 @@ const (
 	colIdxAge      = 8
 	colIdxCmt      = 9
 	colIdxDeps     = 10
+
+	// colIdxChecklist is only shown when a visible issue has acceptance
+	// criteria checkboxes.
+	colIdxChecklist = 11
 )
 
 func (l *ListView) renderTable() string {
@@ -594,18 +953,26 @@ func (l *ListView) renderTable() string {
 		&ui.Column{Header: "AGE", Size: ui.SizeFit, Align: ui.AlignRight, Min: 3, Max: 5},
 		&ui.Column{Header: "CMT", Size: ui.SizeFit, Align: ui.AlignRight, Min: 1, Max: 4},
 		&ui.Column{Header: "DEPS", Size: ui.SizeFit, Align: ui.AlignRight, Min: 4, Max: 7},
+		&ui.Column{Header: "AC", Size: ui.SizeFit, Align: ui.AlignRight, Min: 3, Max: 7},
 	)
 	tbl.Gap = 1
 
+	showChecklist := false
+	for _, issue := range l.filtered {
+		if _, ok := l.checklists[issue.ID]; ok {
+			showChecklist = true
+			break
+		}
+	}
+	if !showChecklist {
+		tbl.Columns = tbl.Columns[:colIdxChecklist]
+	}
+
 	// Scan data to compute max display widths per column (for SizeFit columns).
 	// Uses ui.StringWidth to correctly handle wide/multi-byte characters.
-	dataWidths := make([]int, 11)
+	dataWidths := make([]int, 12)
 	for _, issue := range l.filtered {
-		idDisplay := issue.ID
-		if issue.Pinned {
-			idDisplay = "* " + idDisplay
-		}
-		if n := ui.StringWidth(idDisplay); n > dataWidths[colIdxID] {
+		if n := ui.StringWidth(l.idDisplay(issue)); n > dataWidths[colIdxID] {
 			dataWidths[colIdxID] = n
 		}
 		// PRI is fixed, no scan needed.
@@ -653,6 +1020,9 @@ func (l *ListView) renderTable() string {
 		if n := ui.StringWidth(deps); n > dataWidths[colIdxDeps] {
 			dataWidths[colIdxDeps] = n
 		}
+		if n := ui.StringWidth(l.checklists[issue.ID].String()); n > dataWidths[colIdxChecklist] {
+			dataWidths[colIdxChecklist] = n
+		}
 	}
 	// Ensure assignee column is wide enough for the "-" placeholder.
 	if dataWidths[colIdxAssignee] < 1 {
@@ -664,7 +1034,7 @@ func (l *ListView) renderTable() string {
 	tbl.Resolve(l.width-cursorWidth, dataWidths)
 
 	// Render header row.
-	headers := make([]string, 11)
+	headers := make([]string, len(tbl.Columns))
 	for i, col := range tbl.Columns {
 		headers[i] = col.Header
 	}
@@ -708,13 +1078,8 @@ func (l *ListView) renderTable() string {
 			deps = fmt.Sprintf("%d/%d", issue.DependencyCount, issue.DependentCount)
 		}
 
-		idDisplay := issue.ID
-		if issue.Pinned {
-			idDisplay = "* " + idDisplay
-		}
-
 		cells := []string{
-			idDisplay,
+			l.idDisplay(issue),
 			issue.PriorityString(),
 			issue.Status,
 			issue.IssueType,
@@ -725,17 +1090,29 @@ func (l *ListView) renderTable() string {
 			models.RelativeAge(issue.CreatedAt),
 			cmt,
 			deps,
+			l.checklists[issue.ID].String(),
 		}
 
 		// Style function: pad happens first inside RenderRow, then this
 		// wraps the already-padded plain text in ANSI colors.
-		isOverdue := issue.DueAt != nil && issue.Status != "closed" && time.Now().After(*issue.DueAt)
+		isOverdue := issue.IsOverdue(time.Now())
+		var flashCols map[int]bool
+		if c, ok := l.flashes[issue.ID]; ok && !selected {
+			flashCols = flashColumns(c)
+		}
 		styleFn := func(col int, padded string) string {
+			// Changed cells flash instead of their usual color.
+			if flashCols[col] {
+				return ui.FlashRowStyle.Render(padded)
+			}
 			switch col {
 			case colIdxID:
 				if issue.Pinned {
 					return lipgloss.NewStyle().Foreground(ui.ColorYellow).Render(padded)
 				}
+				if l.stars[issue.ID] {
+					return lipgloss.NewStyle().Foreground(ui.ColorMagenta).Render(padded)
+				}
 				return padded
 			case colIdxPri:
 				return ui.PriorityStyle(issue.Priority).Render(padded)
@@ -744,10 +1121,12 @@ func (l *ListView) renderTable() string {
 			case colIdxType:
 				return ui.TypeStyle(issue.IssueType).Render(padded)
 			case colIdxDue:
-				if isOverdue {
-					return ui.ErrorStyle.Render(padded)
+				return ui.DueStyle(isOverdue).Render(padded)
+			case colIdxChecklist:
+				if l.checklists[issue.ID].Complete() {
+					return lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(padded)
 				}
-				return lipgloss.NewStyle().Foreground(ui.ColorGray).Render(padded)
+				return padded
 			default:
 				return padded
 			}
@@ -757,8 +1136,6 @@ func (l *ListView) renderTable() string {
 
 		if selected {
 			row = ui.SelectedRowStyle.Width(l.width).Render(row)
-		} else if l.flashIDs[issue.ID] {
-			row = ui.FlashRowStyle.Width(l.width).Render(row)
 		}
 		rows = append(rows, row)
 	}
@@ -780,6 +1157,16 @@ func (l *ListView) renderStatusBar() string {
 		)
 	}
 
+	// While the selected row is flashing, describe what changed.
+	if issue := l.SelectedIssue(); issue != nil {
+		if c, ok := l.flashes[issue.ID]; ok {
+			text := ui.Truncate(c.Summary(), max(0, l.width-ui.StringWidth(issue.ID)-3))
+			summary := ui.KeyStyle.Render(issue.ID) + " " +
+				lipgloss.NewStyle().Foreground(ui.ColorYellow).Render(text)
+			return ui.StatusBarStyle.Width(l.width).Render(summary)
+		}
+	}
+
 	closedLabel := "show closed"
 	if !l.hideClosed {
 		closedLabel = "hide closed"
@@ -789,9 +1176,11 @@ func (l *ListView) renderStatusBar() string {
 		{"/", "filter"},
 		{"s", "sort"},
 		{"S", "reverse"},
-		{"1-7", "status"},
+		{"1-8", "status"},
 		{"0", "all"},
 		{"c", closedLabel},
+		{"a", "activity"},
+		{"v", "split"},
 		{"r", "refresh"},
 		{"?", "help"},
 		{"q", "quit"},
@@ -0,0 +1,371 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/analytics"
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// metricsWindows are the selectable chart windows, in days.
+var metricsWindows = []int{14, 30, 60, 90, 180}
+
+// chartHeight is the height of the braille line charts, in rows.
+const chartHeight = 8
+
+// CycleMetricsScopeMsg asks the app to move the metrics view to the next
+// scope (all issues, current filter, epic).
+type CycleMetricsScopeMsg struct{}
+
+// metricsPanel selects what the metrics view shows.
+type metricsPanel int
+
+const (
+	panelCharts metricsPanel = iota // burndown, flow and throughput charts
+	panelReport                     // lead-time breakdowns by group
+)
+
+// MetricsView renders burndown, flow, throughput and lead-time charts for a
+// set of issues, and a lead-time report grouped by type, assignee, label
+// and priority.
+type MetricsView struct {
+	issues    []models.Issue
+	scope     string // description of what issues are in scope
+	windowIdx int    // index into metricsWindows
+	panel     metricsPanel
+	width     int
+	height    int
+	scroll    int
+	lines     []string
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewMetricsView creates a metrics view with a 30-day window.
+func NewMetricsView() *MetricsView {
+	return &MetricsView{windowIdx: 1}
+}
+
+// SetScope replaces the issues being charted.
+func (m *MetricsView) SetScope(issues []models.Issue, scope string) {
+	m.issues = issues
+	m.scope = scope
+	m.buildContent()
+}
+
+// SetSize sets terminal dimensions.
+func (m *MetricsView) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.buildContent()
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (m *MetricsView) SetStatusMsg(msg string) {
+	m.statusMsg = msg
+}
+
+// Update handles key messages.
+func (m *MetricsView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		maxScroll := max(0, len(m.lines)-m.visibleLines())
+		switch msg.String() {
+		case "j", "down":
+			m.scroll = min(m.scroll+1, maxScroll)
+		case "k", "up":
+			m.scroll = max(m.scroll-1, 0)
+		case "g", "home":
+			m.scroll = 0
+		case "G", "end":
+			m.scroll = maxScroll
+		case "ctrl+d":
+			m.scroll = min(m.scroll+m.visibleLines()/2, maxScroll)
+		case "ctrl+u":
+			m.scroll = max(m.scroll-m.visibleLines()/2, 0)
+		case "w":
+			m.windowIdx = (m.windowIdx + 1) % len(metricsWindows)
+			m.buildContent()
+		case "s":
+			return func() tea.Msg { return CycleMetricsScopeMsg{} }
+		case "tab":
+			m.panel = (m.panel + 1) % 2
+			m.scroll = 0
+			m.buildContent()
+		}
+	}
+	return nil
+}
+
+func (m *MetricsView) visibleLines() int {
+	return ui.ContentHeight(m.height, m.renderHeader(), m.renderStatusBar())
+}
+
+func (m *MetricsView) buildContent() {
+	if m.panel == panelReport {
+		m.buildReport()
+		return
+	}
+	days := metricsWindows[m.windowIdx]
+	now := time.Now()
+	width := max(20, m.width-4)
+	gray := lipgloss.NewStyle().Foreground(ui.ColorGray)
+
+	var lines []string
+	add := func(s ...string) { lines = append(lines, s...) }
+	section := func(title string) {
+		add("", ui.SectionHeaderStyle.Render(title), ui.TableHeaderStyle.Width(width).Render(""))
+	}
+	legend := func(color lipgloss.Color, label string) string {
+		return lipgloss.NewStyle().Foreground(color).Render("━━ ") + gray.Render(label)
+	}
+
+	daily := analytics.DailySeries(m.issues, days, now)
+	created, closed := 0, 0
+	for i := range daily.Created {
+		created += daily.Created[i]
+		closed += daily.Closed[i]
+	}
+	openNow := 0
+	if n := len(daily.Open); n > 0 {
+		openNow = daily.Open[n-1]
+	}
+	field := func(label, value string) {
+		add(ui.FieldLabelStyle.Render(label) + ui.FieldValueStyle.Render(value))
+	}
+	field("Issues", fmt.Sprintf("%d in scope, %d open now", len(m.issues), openNow))
+	field("Last "+fmt.Sprintf("%dd", days), fmt.Sprintf("%d created, %d closed (net %+d)", created, closed, created-closed))
+
+	// Per-day sparklines.
+	section("CREATED / CLOSED PER DAY")
+	sparkWidth := max(10, width-16)
+	add(
+		ui.FieldLabelStyle.Render("created")+lipgloss.NewStyle().Foreground(ui.ColorBlue).Render(ui.Sparkline(daily.Created, sparkWidth)),
+		ui.FieldLabelStyle.Render("closed")+lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(ui.Sparkline(daily.Closed, sparkWidth)),
+		gray.Render(strings.Repeat(" ", 14)+axisLabels(daily.Start, now, min(sparkWidth, days))),
+	)
+
+	// Burndown: open issues at the end of each day.
+	section("BURNDOWN (open issues)")
+	add(ui.LineChart([]ui.Series{{Values: toFloats(daily.Open), Color: ui.ColorYellow}}, width, chartHeight)...)
+	add(gray.Render(axisLabels(daily.Start, now, width)))
+
+	// Cumulative flow. bd only exposes created/closed timestamps, not status
+	// history, so the flow has two bands: arrived and done. The gap between
+	// the lines is work in progress.
+	section("CUMULATIVE FLOW")
+	before := 0
+	for _, issue := range m.issues {
+		if analytics.Day(issue.CreatedAt).Before(daily.Start) {
+			before++
+		}
+	}
+	cumCreated := analytics.Cumulative(daily.Created)
+	cumClosed := make([]int, len(daily.Open))
+	for i := range cumCreated {
+		cumCreated[i] += before
+		cumClosed[i] = cumCreated[i] - daily.Open[i]
+	}
+	add(ui.LineChart([]ui.Series{
+		{Values: toFloats(cumCreated), Color: ui.ColorBlue},
+		{Values: toFloats(cumClosed), Color: ui.ColorGreen},
+	}, width, chartHeight)...)
+	add(gray.Render(axisLabels(daily.Start, now, width)))
+	add(legend(ui.ColorBlue, "created") + "   " + legend(ui.ColorGreen, "closed"))
+
+	// Current status distribution.
+	counts := analytics.StatusCounts(m.issues)
+	var parts []ui.BarPart
+	var keys []string
+	for _, st := range []string{"open", "in_progress", "blocked", "deferred", "closed"} {
+		if counts[st] == 0 {
+			continue
+		}
+		parts = append(parts, ui.BarPart{Value: counts[st], Color: ui.StatusColor(st)})
+		keys = append(keys, ui.StatusStyle(st).Render(fmt.Sprintf("%s %d", st, counts[st])))
+	}
+	if len(parts) > 0 {
+		add("", ui.StackedBar(parts, width), strings.Join(keys, "  "))
+	}
+
+	// Weekly throughput.
+	section("WEEKLY THROUGHPUT (closed)")
+	weeks := analytics.Weekly(daily.Closed)
+	peak := 0
+	for _, w := range weeks {
+		peak = max(peak, w)
+	}
+	if len(weeks) == 0 {
+		add(gray.Render("Window is shorter than a week."))
+	}
+	barWidth := max(10, width-20)
+	for i, w := range weeks {
+		weekEnd := analytics.Day(now).AddDate(0, 0, -7*(len(weeks)-1-i))
+		label := "wk of " + weekEnd.AddDate(0, 0, -6).Format("Jan 02")
+		add(ui.PadStr(label, 14) + lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(ui.HBar(w, peak, barWidth)) +
+			" " + fmt.Sprintf("%d", w))
+	}
+
+	// Lead time distribution.
+	section("LEAD TIME")
+	leads := analytics.LeadTimes(m.issues)
+	if len(leads) == 0 {
+		add(gray.Render("No closed issues in scope."))
+	} else {
+		add(fmt.Sprintf("%s %s   %s %s   %s %s   %s",
+			ui.KeyStyle.Render("p50"), models.FormatDuration(analytics.Percentile(leads, 50)),
+			ui.KeyStyle.Render("p90"), models.FormatDuration(analytics.Percentile(leads, 90)),
+			ui.KeyStyle.Render("mean"), models.FormatDuration(analytics.Mean(leads)),
+			gray.Render(fmt.Sprintf("(%d closed issues)", len(leads))),
+		), "")
+		hist := analytics.LeadTimeHistogram(leads)
+		peak := 0
+		for _, b := range hist {
+			peak = max(peak, b.Count)
+		}
+		for _, b := range hist {
+			add(ui.PadStr(b.Label, 6) + lipgloss.NewStyle().Foreground(ui.ColorCyan).Render(ui.HBar(b.Count, peak, barWidth)) +
+				" " + fmt.Sprintf("%d", b.Count))
+		}
+	}
+
+	m.lines = lines
+	m.scroll = min(m.scroll, max(0, len(m.lines)-m.visibleLines()))
+}
+
+// buildReport renders lead-time stats of the issues closed in the window,
+// grouped along every dimension.
+func (m *MetricsView) buildReport() {
+	days := metricsWindows[m.windowIdx]
+	width := max(20, m.width-4)
+	gray := lipgloss.NewStyle().Foreground(ui.ColorGray)
+	r := analytics.BuildReport(m.issues, analytics.LastDays(days, time.Now()), analytics.Dimensions)
+
+	var lines []string
+	add := func(s ...string) { lines = append(lines, s...) }
+	add(gray.Render(fmt.Sprintf("Lead time of issues closed in the last %dd. ACTUAL/EST is the median of lead time over estimate (8h days).", days)))
+
+	keyWidth := 16
+	for _, b := range r.Breakdowns {
+		for _, g := range b.Groups {
+			keyWidth = max(keyWidth, min(ui.StringWidth(g.Key)+2, 30))
+		}
+	}
+	row := func(key, count, mean, median, p90, est string) string {
+		return ui.PadStr(ui.Truncate(key, keyWidth-2), keyWidth) + ui.PadStr(count, 7) +
+			ui.PadStr(mean, 10) + ui.PadStr(median, 10) + ui.PadStr(p90, 10) + est
+	}
+	group := func(g analytics.GroupStats) string {
+		est := gray.Render("-")
+		if g.Estimated > 0 {
+			style := lipgloss.NewStyle().Foreground(ui.ColorGreen)
+			if g.EstimateRatio > 1.5 {
+				style = lipgloss.NewStyle().Foreground(ui.ColorRed)
+			}
+			est = style.Render(fmt.Sprintf("%.1fx", g.EstimateRatio)) + gray.Render(fmt.Sprintf(" (%d)", g.Estimated))
+		}
+		return row(g.Key, fmt.Sprintf("%d", g.Count), models.FormatDuration(g.Mean),
+			models.FormatDuration(g.Median), models.FormatDuration(g.P90), est)
+	}
+	table := func(title string, groups []analytics.GroupStats) {
+		add("", ui.SectionHeaderStyle.Render(title),
+			ui.TableHeaderStyle.Width(width).Render(row("", "COUNT", "MEAN", "MEDIAN", "P90", "ACTUAL/EST")))
+		for _, g := range groups {
+			add(group(g))
+		}
+	}
+
+	if r.Overall.Count == 0 {
+		add("", gray.Render("No issues in scope were closed in this window."))
+		m.lines = lines
+		m.scroll = 0
+		return
+	}
+	table("OVERALL", []analytics.GroupStats{r.Overall})
+	for _, b := range r.Breakdowns {
+		table("BY "+strings.ToUpper(string(b.Dimension)), b.Groups)
+	}
+
+	m.lines = lines
+	m.scroll = min(m.scroll, max(0, len(m.lines)-m.visibleLines()))
+}
+
+// axisLabels renders start/end date labels spread across width columns.
+func axisLabels(start, end time.Time, width int) string {
+	left := start.Format("Jan 02")
+	right := end.Format("Jan 02")
+	gap := width - len(left) - len(right)
+	if gap < 1 {
+		return left
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+func toFloats(vals []int) []float64 {
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+// View renders the metrics view.
+func (m *MetricsView) View() string {
+	vis := m.visibleLines()
+	end := min(m.scroll+vis, len(m.lines))
+	visible := make([]string, 0, vis)
+	for i := m.scroll; i < end; i++ {
+		visible = append(visible, "  "+m.lines[i])
+	}
+	for len(visible) < vis {
+		visible = append(visible, "")
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(strings.Join(visible, "\n"))
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	return b.String()
+}
+
+func (m *MetricsView) renderHeader() string {
+	left := ui.LogoStyle.Render("metrics")
+	if m.panel == panelReport {
+		left = ui.LogoStyle.Render("lead time report")
+	}
+	right := ui.KeyStyle.Render("scope:") + " " + ui.KeyDescStyle.Render(m.scope) + "  " +
+		ui.KeyStyle.Render("window:") + " " + ui.KeyDescStyle.Render(fmt.Sprintf("%dd", metricsWindows[m.windowIdx]))
+	gap := max(0, m.width-lipgloss.Width(left)-lipgloss.Width(right)-2)
+	return ui.HeaderStyle.Width(m.width).Render(left + strings.Repeat(" ", gap) + right)
+}
+
+func (m *MetricsView) renderStatusBar() string {
+	if m.statusMsg != "" {
+		return ui.StatusBarStyle.Width(m.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(m.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"j/k", "scroll"},
+		{"tab", "charts/report"},
+		{"s", "scope"},
+		{"w", "window"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(m.width).Render(strings.Join(parts, "  "))
+}
@@ -0,0 +1,244 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/triage"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// ClaimIssueMsg asks the app to claim an issue (in_progress, assigned to me).
+type ClaimIssueMsg struct {
+	ID string
+}
+
+// NextView is the "what should I work on" picker: ready issues ranked by
+// score, with the reasoning for each.
+type NextView struct {
+	ranked []triage.Scored
+	me     string
+	cursor int
+	offset int
+	width  int
+	height int
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewNextView creates an empty next-task picker.
+func NewNextView() *NextView {
+	return &NextView{}
+}
+
+// SetData replaces the ranked candidates. The cursor stays on the same
+// issue if it is still ready.
+func (n *NextView) SetData(ranked []triage.Scored, me string) {
+	selected := n.SelectedID()
+	n.ranked = ranked
+	n.me = me
+	n.cursor = 0
+	for i, s := range ranked {
+		if s.Issue.ID == selected {
+			n.cursor = i
+			break
+		}
+	}
+	n.ensureVisible()
+}
+
+// ResetCursor moves the cursor back to the top-ranked issue.
+func (n *NextView) ResetCursor() {
+	n.cursor = 0
+	n.offset = 0
+}
+
+// SetSize sets terminal dimensions.
+func (n *NextView) SetSize(w, h int) {
+	n.width = w
+	n.height = h
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (n *NextView) SetStatusMsg(msg string) {
+	n.statusMsg = msg
+}
+
+// SelectedID returns the issue ID under the cursor, or "".
+func (n *NextView) SelectedID() string {
+	if n.cursor >= len(n.ranked) {
+		return ""
+	}
+	return n.ranked[n.cursor].Issue.ID
+}
+
+// Update handles key messages for the picker.
+func (n *NextView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if n.cursor < len(n.ranked)-1 {
+				n.cursor++
+				n.ensureVisible()
+			}
+		case "k", "up":
+			if n.cursor > 0 {
+				n.cursor--
+				n.ensureVisible()
+			}
+		case "g", "home":
+			n.ResetCursor()
+		case "G", "end":
+			n.cursor = max(0, len(n.ranked)-1)
+			n.ensureVisible()
+		case "enter":
+			if id := n.SelectedID(); id != "" {
+				return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+			}
+		case "c":
+			if id := n.SelectedID(); id != "" {
+				return func() tea.Msg { return ClaimIssueMsg{ID: id} }
+			}
+		}
+	}
+	return nil
+}
+
+func (n *NextView) visibleRows() int {
+	tblHdr := ui.TableHeaderStyle.Width(n.width).Render("")
+	return ui.ContentHeight(n.height, n.renderHeader(), tblHdr, n.renderStatusBar())
+}
+
+func (n *NextView) ensureVisible() {
+	vis := n.visibleRows()
+	if n.cursor < n.offset {
+		n.offset = n.cursor
+	}
+	if n.cursor >= n.offset+vis {
+		n.offset = n.cursor - vis + 1
+	}
+}
+
+// View renders the picker.
+func (n *NextView) View() string {
+	var b strings.Builder
+	b.WriteString(n.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(n.renderTable())
+	b.WriteString("\n")
+	b.WriteString(n.renderStatusBar())
+	return b.String()
+}
+
+func (n *NextView) renderHeader() string {
+	left := ui.LogoStyle.Render("next") + "  " + fmt.Sprintf("%d ready issues", len(n.ranked))
+	right := ""
+	if n.me != "" {
+		right = ui.KeyStyle.Render("me:") + " " + ui.KeyDescStyle.Render(n.me)
+	}
+	gap := max(0, n.width-lipgloss.Width(left)-lipgloss.Width(right)-2)
+	return ui.HeaderStyle.Width(n.width).Render(left + strings.Repeat(" ", gap) + right)
+}
+
+func (n *NextView) renderTable() string {
+	if len(n.ranked) == 0 {
+		emptyHeight := max(1, n.height-6)
+		return strings.Repeat("\n", emptyHeight/2) + lipgloss.NewStyle().
+			Width(n.width).
+			Align(lipgloss.Center).
+			Foreground(ui.ColorGray).
+			Render("Nothing is ready. Everything open is blocked or deferred.")
+	}
+
+	tbl := ui.NewTable(
+		&ui.Column{Header: "#", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 3},
+		&ui.Column{Header: "ID", Size: ui.SizeFit, Align: ui.AlignLeft, Min: 4, Max: 20},
+		&ui.Column{Header: "PRI", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 3},
+		&ui.Column{Header: "SCORE", Size: ui.SizeFixed, Align: ui.AlignLeft, Fixed: 5},
+		&ui.Column{Header: "TITLE", Size: ui.SizeFlex, Align: ui.AlignLeft, Min: 10, Max: 60},
+		&ui.Column{Header: "WHY", Size: ui.SizeFlex, Align: ui.AlignLeft, Min: 10},
+	)
+	dataWidths := make([]int, 6)
+	for _, s := range n.ranked {
+		if w := ui.StringWidth(s.Issue.ID); w > dataWidths[1] {
+			dataWidths[1] = w
+		}
+	}
+	tbl.Resolve(n.width-2, dataWidths)
+
+	headers := make([]string, len(tbl.Columns))
+	for i, col := range tbl.Columns {
+		headers[i] = col.Header
+	}
+	rows := []string{ui.TableHeaderStyle.Width(n.width).Render("  " + tbl.RenderRow(headers, nil))}
+
+	vis := n.visibleRows()
+	end := min(n.offset+vis, len(n.ranked))
+	for i := n.offset; i < end; i++ {
+		s := n.ranked[i]
+		selected := i == n.cursor
+		cursor := "  "
+		if selected {
+			cursor = "> "
+		}
+		reasons := make([]string, len(s.Reasons))
+		for j, r := range s.Reasons {
+			reasons[j] = r.String()
+		}
+		cells := []string{
+			fmt.Sprintf("%d", i+1),
+			s.Issue.ID,
+			s.Issue.PriorityString(),
+			fmt.Sprintf("%.1f", s.Score),
+			s.Issue.Title,
+			strings.Join(reasons, ", "),
+		}
+		styleFn := func(col int, padded string) string {
+			switch col {
+			case 2:
+				return ui.PriorityStyle(s.Issue.Priority).Render(padded)
+			case 3:
+				return lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(padded)
+			case 5:
+				return lipgloss.NewStyle().Foreground(ui.ColorGray).Render(padded)
+			default:
+				return padded
+			}
+		}
+		row := cursor + tbl.RenderRow(cells, styleFn)
+		if selected {
+			row = ui.SelectedRowStyle.Width(n.width).Render(row)
+		}
+		rows = append(rows, row)
+	}
+	for len(rows)-1 < vis {
+		rows = append(rows, strings.Repeat(" ", n.width))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (n *NextView) renderStatusBar() string {
+	if n.statusMsg != "" {
+		return ui.StatusBarStyle.Width(n.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(n.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"c", "claim"},
+		{"enter", "view"},
+		{"j/k", "move"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(n.width).Render(strings.Join(parts, "  "))
+}
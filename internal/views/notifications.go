@@ -0,0 +1,320 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/config"
+	"github.com/poiley/beady/internal/notify"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// maxNotifications caps the notification center history.
+const maxNotifications = 200
+
+// AddWatchMsg asks the app to add and persist a query watch.
+type AddWatchMsg struct {
+	Query string
+}
+
+// RemoveWatchMsg asks the app to remove and persist the removal of a watch.
+type RemoveWatchMsg struct {
+	Index int
+}
+
+// notifPane identifies which pane of the notification center has focus.
+type notifPane int
+
+const (
+	paneNotifications notifPane = iota
+	paneWatches
+)
+
+// NotificationsView is the notification center: the configured watches
+// and the notifications raised this session.
+type NotificationsView struct {
+	watches []config.Watch
+	notes   []notify.Notification // newest first
+	unread  int
+
+	pane        notifPane
+	noteCursor  int
+	watchCursor int
+	offset      int
+	width       int
+	height      int
+
+	// Query input for adding a watch.
+	input  textinput.Model
+	adding bool
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewNotificationsView creates an empty notification center.
+func NewNotificationsView() *NotificationsView {
+	ti := textinput.New()
+	ti.Placeholder = "query, e.g. p0 type:bug  or  ready assignee:alice"
+	ti.CharLimit = 200
+	return &NotificationsView{input: ti}
+}
+
+// SetWatches replaces the list of configured watches.
+func (n *NotificationsView) SetWatches(watches []config.Watch) {
+	n.watches = watches
+	if n.watchCursor >= len(n.watches) {
+		n.watchCursor = max(0, len(n.watches)-1)
+	}
+}
+
+// Add prepends new notifications and counts them as unread.
+func (n *NotificationsView) Add(notes []notify.Notification) {
+	if len(notes) == 0 {
+		return
+	}
+	n.notes = append(append([]notify.Notification{}, notes...), n.notes...)
+	if len(n.notes) > maxNotifications {
+		n.notes = n.notes[:maxNotifications]
+	}
+	n.unread += len(notes)
+}
+
+// Unread returns the number of notifications not yet seen.
+func (n *NotificationsView) Unread() int {
+	return n.unread
+}
+
+// MarkRead clears the unread count (called when the center is opened).
+func (n *NotificationsView) MarkRead() {
+	n.unread = 0
+}
+
+// SetSize sets terminal dimensions.
+func (n *NotificationsView) SetSize(w, h int) {
+	n.width = w
+	n.height = h
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (n *NotificationsView) SetStatusMsg(msg string) {
+	n.statusMsg = msg
+}
+
+// IsFiltering returns whether the query input is active.
+func (n *NotificationsView) IsFiltering() bool {
+	return n.adding
+}
+
+// SelectedIssueID returns the issue of the selected notification, or "".
+func (n *NotificationsView) SelectedIssueID() string {
+	if n.pane != paneNotifications || n.noteCursor >= len(n.notes) {
+		return ""
+	}
+	return n.notes[n.noteCursor].IssueID
+}
+
+// Update handles key messages for the notification center.
+func (n *NotificationsView) Update(msg tea.Msg) tea.Cmd {
+	if n.adding {
+		return n.updateAdding(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab":
+			if n.pane == paneNotifications {
+				n.pane = paneWatches
+			} else {
+				n.pane = paneNotifications
+			}
+		case "j", "down":
+			if n.pane == paneWatches {
+				if n.watchCursor < len(n.watches)-1 {
+					n.watchCursor++
+				}
+			} else if n.noteCursor < len(n.notes)-1 {
+				n.noteCursor++
+				n.ensureVisible()
+			}
+		case "k", "up":
+			if n.pane == paneWatches {
+				if n.watchCursor > 0 {
+					n.watchCursor--
+				}
+			} else if n.noteCursor > 0 {
+				n.noteCursor--
+				n.ensureVisible()
+			}
+		case "g", "home":
+			n.noteCursor = 0
+			n.offset = 0
+		case "G", "end":
+			n.noteCursor = max(0, len(n.notes)-1)
+			n.ensureVisible()
+		case "a":
+			n.adding = true
+			n.input.SetValue("")
+			n.input.Focus()
+			return textinput.Blink
+		case "d", "delete":
+			if n.pane == paneWatches && n.watchCursor < len(n.watches) {
+				idx := n.watchCursor
+				return func() tea.Msg { return RemoveWatchMsg{Index: idx} }
+			}
+		case "enter":
+			if id := n.SelectedIssueID(); id != "" {
+				return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+			}
+		}
+	}
+	return nil
+}
+
+func (n *NotificationsView) updateAdding(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			query := strings.TrimSpace(n.input.Value())
+			n.adding = false
+			n.input.Blur()
+			if query == "" {
+				return nil
+			}
+			return func() tea.Msg { return AddWatchMsg{Query: query} }
+		case "esc":
+			n.adding = false
+			n.input.Blur()
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	n.input, cmd = n.input.Update(msg)
+	return cmd
+}
+
+// watchPaneHeight is the number of lines taken by the watches pane.
+func (n *NotificationsView) watchPaneHeight() int {
+	return 2 + max(1, len(n.watches))
+}
+
+func (n *NotificationsView) visibleRows() int {
+	chrome := []string{n.renderHeader(), n.renderStatusBar(), strings.Repeat("\n", n.watchPaneHeight()+1)}
+	if n.adding {
+		chrome = append(chrome, " ")
+	}
+	return ui.ContentHeight(n.height, chrome...)
+}
+
+func (n *NotificationsView) ensureVisible() {
+	vis := n.visibleRows()
+	if n.noteCursor < n.offset {
+		n.offset = n.noteCursor
+	}
+	if n.noteCursor >= n.offset+vis {
+		n.offset = n.noteCursor - vis + 1
+	}
+}
+
+// View renders the notification center.
+func (n *NotificationsView) View() string {
+	var b strings.Builder
+	b.WriteString(n.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(n.renderWatches())
+	b.WriteString("\n")
+	b.WriteString(n.renderNotifications())
+	if n.adding {
+		b.WriteString("\n")
+		b.WriteString(ui.FilterPromptStyle.Render("watch:") + " " + n.input.View())
+	}
+	b.WriteString("\n")
+	b.WriteString(n.renderStatusBar())
+	return b.String()
+}
+
+func (n *NotificationsView) renderHeader() string {
+	left := ui.LogoStyle.Render("notifications") + "  " +
+		fmt.Sprintf("%d watches  %d notifications", len(n.watches), len(n.notes))
+	return ui.HeaderStyle.Width(n.width).Render(left)
+}
+
+func (n *NotificationsView) paneTitle(title string, pane notifPane) string {
+	style := ui.SectionHeaderStyle
+	if n.pane != pane {
+		style = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorGray)
+	}
+	return ui.TableHeaderStyle.Width(n.width).Render("  " + style.Render(title))
+}
+
+func (n *NotificationsView) renderWatches() string {
+	lines := []string{n.paneTitle("WATCHES", paneWatches)}
+	if len(n.watches) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorGray).
+			Render("  No watches. Press w on an issue, or a here to watch a query."))
+	}
+	for i, w := range n.watches {
+		kind := "query"
+		if w.IssueID != "" {
+			kind = "issue"
+		}
+		line := fmt.Sprintf("  %-6s %s", kind, w.String())
+		if n.pane == paneWatches && i == n.watchCursor {
+			line = ui.SelectedRowStyle.Width(n.width).Render("> " + line[2:])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (n *NotificationsView) renderNotifications() string {
+	lines := []string{n.paneTitle("RECENT", paneNotifications)}
+	vis := n.visibleRows()
+	if len(n.notes) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorGray).
+			Render("  Nothing yet. Notifications appear here when a watch fires."))
+	}
+	end := min(n.offset+vis, len(n.notes))
+	for i := n.offset; i < end; i++ {
+		note := n.notes[i]
+		ts := lipgloss.NewStyle().Foreground(ui.ColorGray).Render(formatActivityTime(note.At))
+		text := ui.Truncate(note.Title+" — "+note.Body, max(10, n.width-14))
+		line := "  " + ts + "  " + text
+		if n.pane == paneNotifications && i == n.noteCursor {
+			line = ui.SelectedRowStyle.Width(n.width).Render("> " + ts + "  " + text)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines)-1 < vis {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (n *NotificationsView) renderStatusBar() string {
+	if n.statusMsg != "" {
+		return ui.StatusBarStyle.Width(n.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(n.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"tab", "switch pane"},
+		{"enter", "view"},
+		{"a", "add query watch"},
+		{"d", "remove watch"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(n.width).Render(strings.Join(parts, "  "))
+}
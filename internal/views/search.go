@@ -0,0 +1,313 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/poiley/beady/internal/models"
+	"github.com/poiley/beady/internal/search"
+	"github.com/poiley/beady/internal/ui"
+)
+
+// searchLimit caps how many ranked results are shown.
+const searchLimit = 200
+
+// SearchView is full-text search over issue bodies and comments, backed
+// by a search.Index the app keeps up to date.
+type SearchView struct {
+	index   *search.Index
+	issues  map[string]*models.Issue
+	results []search.Result
+	cursor  int
+	offset  int
+	width   int
+	height  int
+
+	input  textinput.Model
+	typing bool
+	query  string
+
+	// Indexing progress: indexed of total issues.
+	indexed int
+	total   int
+
+	// Temporary status message shown in the status bar.
+	statusMsg string
+}
+
+// NewSearchView creates a search view over index.
+func NewSearchView(index *search.Index) *SearchView {
+	ti := textinput.New()
+	ti.Placeholder = "search descriptions, notes, comments..."
+	ti.CharLimit = 200
+	return &SearchView{index: index, input: ti}
+}
+
+// Open focuses the query input.
+func (s *SearchView) Open() tea.Cmd {
+	s.typing = true
+	s.input.Focus()
+	return textinput.Blink
+}
+
+// SetData updates the issue metadata shown next to results.
+func (s *SearchView) SetData(issues []models.Issue) {
+	s.issues = make(map[string]*models.Issue, len(issues))
+	for i := range issues {
+		s.issues[issues[i].ID] = &issues[i]
+	}
+}
+
+// SetProgress records how much of the issue list has been indexed.
+func (s *SearchView) SetProgress(indexed, total int) {
+	s.indexed = indexed
+	s.total = total
+}
+
+// Refresh re-runs the query against the index, keeping the cursor on the
+// same issue when it is still a result.
+func (s *SearchView) Refresh() {
+	selected := s.SelectedID()
+	s.results = s.index.Search(s.query, searchLimit)
+	s.cursor = 0
+	for i, r := range s.results {
+		if r.ID == selected {
+			s.cursor = i
+			break
+		}
+	}
+	s.ensureVisible()
+}
+
+// SetSize sets terminal dimensions.
+func (s *SearchView) SetSize(w, h int) {
+	s.width = w
+	s.height = h
+}
+
+// SetStatusMsg sets a temporary status bar message.
+func (s *SearchView) SetStatusMsg(msg string) {
+	s.statusMsg = msg
+}
+
+// IsTyping returns whether the query input has focus.
+func (s *SearchView) IsTyping() bool {
+	return s.typing
+}
+
+// Query returns the current search query.
+func (s *SearchView) Query() string {
+	return s.query
+}
+
+// SelectedID returns the issue ID under the cursor, or "".
+func (s *SearchView) SelectedID() string {
+	if s.cursor >= len(s.results) {
+		return ""
+	}
+	return s.results[s.cursor].ID
+}
+
+// Update handles key messages.
+func (s *SearchView) Update(msg tea.Msg) tea.Cmd {
+	if s.typing {
+		return s.updateTyping(msg)
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+				s.ensureVisible()
+			}
+		case "k", "up":
+			if s.cursor > 0 {
+				s.cursor--
+				s.ensureVisible()
+			}
+		case "g", "home":
+			s.cursor = 0
+			s.offset = 0
+		case "G", "end":
+			s.cursor = max(0, len(s.results)-1)
+			s.ensureVisible()
+		case "/":
+			return s.Open()
+		case "enter":
+			if id := s.SelectedID(); id != "" {
+				return func() tea.Msg { return NavigateToIssueMsg{ID: id} }
+			}
+		}
+	}
+	return nil
+}
+
+// updateTyping handles keys while the query input has focus. Results
+// update as you type; enter or the arrow keys move to the results.
+func (s *SearchView) updateTyping(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter", "esc", "down", "up":
+			s.typing = false
+			s.input.Blur()
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	if q := s.input.Value(); q != s.query {
+		s.query = q
+		s.cursor = 0
+		s.offset = 0
+		s.Refresh()
+	}
+	return cmd
+}
+
+// visibleResults returns how many results fit; each takes two lines.
+func (s *SearchView) visibleResults() int {
+	return max(1, ui.ContentHeight(s.height, s.renderHeader(), s.renderInput(), s.renderStatusBar())/2)
+}
+
+func (s *SearchView) ensureVisible() {
+	vis := s.visibleResults()
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+vis {
+		s.offset = s.cursor - vis + 1
+	}
+}
+
+// View renders the search view.
+func (s *SearchView) View() string {
+	var b strings.Builder
+	b.WriteString(s.renderHeader())
+	b.WriteString("\n")
+	b.WriteString(s.renderInput())
+	b.WriteString("\n")
+	b.WriteString(s.renderResults())
+	b.WriteString("\n")
+	b.WriteString(s.renderStatusBar())
+	return b.String()
+}
+
+func (s *SearchView) renderHeader() string {
+	left := ui.LogoStyle.Render("search")
+	if s.query != "" {
+		left += "  " + fmt.Sprintf("%d results", len(s.results))
+		if len(s.results) == searchLimit {
+			left += fmt.Sprintf(" (top %d)", searchLimit)
+		}
+	}
+	right := ui.KeyDescStyle.Render(fmt.Sprintf("%d issues indexed", s.indexed))
+	if s.indexed < s.total {
+		right = lipgloss.NewStyle().Foreground(ui.ColorYellow).Render(
+			fmt.Sprintf("indexing %d/%d", s.indexed, s.total))
+	}
+	gap := max(0, s.width-lipgloss.Width(left)-lipgloss.Width(right)-2)
+	return ui.HeaderStyle.Width(s.width).Render(left + strings.Repeat(" ", gap) + right)
+}
+
+func (s *SearchView) renderInput() string {
+	if s.typing {
+		return ui.FilterPromptStyle.Render("/") + " " + s.input.View()
+	}
+	if s.query == "" {
+		return ui.KeyDescStyle.Render("/ to search")
+	}
+	return ui.FilterPromptStyle.Render("/") + " " + ui.FilterInputStyle.Render(s.query)
+}
+
+func (s *SearchView) renderResults() string {
+	vis := s.visibleResults()
+	if len(s.results) == 0 {
+		msg := "Type to search titles, descriptions, design, acceptance criteria, notes and comments."
+		if s.query != "" {
+			msg = "No matches."
+			if s.indexed < s.total {
+				msg += " Still indexing; results will appear as issues are fetched."
+			}
+		}
+		return strings.Repeat("\n", vis/2) + lipgloss.NewStyle().
+			Width(s.width).
+			Align(lipgloss.Center).
+			Foreground(ui.ColorGray).
+			Render(msg) + strings.Repeat("\n", max(0, 2*vis-vis/2-1))
+	}
+
+	fieldStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
+	snippetStyle := lipgloss.NewStyle().Foreground(ui.ColorGray)
+	var rows []string
+	end := min(s.offset+vis, len(s.results))
+	for i := s.offset; i < end; i++ {
+		r := s.results[i]
+		selected := i == s.cursor
+
+		cursor := "  "
+		if selected {
+			cursor = "> "
+		}
+		head := cursor + ui.LogoStyle.Render(r.ID)
+		if issue := s.issues[r.ID]; issue != nil {
+			head += "  " + ui.PriorityStyle(issue.Priority).Render(issue.PriorityString()) +
+				"  " + ui.StatusStyle(issue.Status).Render(issue.Status)
+		}
+		head += "  " + ui.Truncate(r.Title, max(10, s.width-lipgloss.Width(head)-3))
+		if selected {
+			head = ui.SelectedRowStyle.Width(s.width).Render(head)
+		}
+		rows = append(rows, head)
+
+		line := ""
+		if r.Snippet != "" {
+			label := r.Field + ": "
+			avail := max(10, s.width-6-ui.StringWidth(label))
+			text := ui.Truncate(r.Snippet, avail)
+			var matches []ui.Match
+			for _, sp := range r.Spans {
+				if sp.End <= len(text) && text[:sp.End] == r.Snippet[:sp.End] {
+					matches = append(matches, ui.Match{Start: sp.Start, End: sp.End})
+				}
+			}
+			line = "      " + fieldStyle.Render(label) + ui.Highlight(snippetStyle.Render(text), matches, -1)
+		}
+		rows = append(rows, line)
+	}
+	for len(rows) < 2*vis {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (s *SearchView) renderStatusBar() string {
+	if s.statusMsg != "" {
+		return ui.StatusBarStyle.Width(s.width).Render(
+			lipgloss.NewStyle().Foreground(ui.ColorGreen).Render(s.statusMsg),
+		)
+	}
+	keys := []struct{ key, desc string }{
+		{"esc", "back"},
+		{"/", "edit query"},
+		{"enter", "view"},
+		{"j/k", "move"},
+		{"?", "help"},
+		{"q", "quit"},
+	}
+	if s.typing {
+		keys = []struct{ key, desc string }{
+			{"enter", "results"},
+			{"esc", "done"},
+		}
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, ui.KeyStyle.Render(k.key)+" "+ui.KeyDescStyle.Render(k.desc))
+	}
+	return ui.StatusBarStyle.Width(s.width).Render(strings.Join(parts, "  "))
+}